@@ -0,0 +1,110 @@
+package accounting
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Amount is a quantity of a commodity
+type Amount struct {
+	Value    float64
+	Currency string
+}
+
+// Posting is one leg of a balanced entry. Price, when set, is the total cost
+// of the posting in another commodity, written as "@@" in ledger formats.
+type Posting struct {
+	Account string
+	Amount  Amount
+	Price   *Amount
+	Memo    string
+}
+
+// Entry is a balanced journal transaction for one transfer
+type Entry struct {
+	Date       time.Time
+	Payee      string
+	Reference  string
+	TransferID int
+	Rate       float64
+	Postings   []Posting
+}
+
+// TransferEntry builds the entry for a transfer: the payout is credited to
+// the expense account at the recorded rate, the fee is booked as an expense,
+// and the source balance is debited by the sum of both. The converted amount
+// is derived from the rounded source and fee so the postings always balance.
+func TransferEntry(date time.Time, transferID int, payee, reference string, source, target Amount, fee, rate float64, expenseAccount, feesAccount, assetsAccount string) Entry {
+	feeRounded := round(fee)
+	converted := round(round(source.Value) - feeRounded)
+
+	payout := Posting{Account: expenseAccount, Amount: target}
+	if target.Currency != source.Currency {
+		payout.Price = &Amount{Value: converted, Currency: source.Currency}
+	} else {
+		payout.Amount.Value = converted
+	}
+
+	postings := []Posting{payout}
+	if feeRounded != 0 {
+		postings = append(postings, Posting{Account: feesAccount, Amount: Amount{Value: feeRounded, Currency: source.Currency}, Memo: "Wise fee"})
+	}
+	postings = append(postings, Posting{Account: assetsAccount, Amount: Amount{Value: -round(source.Value), Currency: source.Currency}})
+
+	return Entry{
+		Date:       date,
+		Payee:      payee,
+		Reference:  reference,
+		TransferID: transferID,
+		Rate:       rate,
+		Postings:   postings,
+	}
+}
+
+// Formats lists the supported export formats
+var Formats = []string{"ledger", "hledger", "beancount", "qif", "xero-csv"}
+
+// Write renders entries in the given format
+func Write(w io.Writer, format string, entries []Entry) error {
+	switch format {
+	case "ledger":
+		return writeLedger(w, entries, ledgerStyle)
+	case "hledger":
+		return writeLedger(w, entries, hledgerStyle)
+	case "beancount":
+		return writeBeancount(w, entries)
+	case "qif":
+		return writeQIF(w, entries)
+	case "xero-csv":
+		return writeXeroCSV(w, entries)
+	}
+	return fmt.Errorf("unsupported format %q: use %s", format, strings.Join(Formats, ", "))
+}
+
+// SourceCurrencies returns the currencies debited by the entries, sorted
+func SourceCurrencies(entries []Entry) []string {
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		seen[e.source().Amount.Currency] = true
+	}
+	currencies := make([]string, 0, len(seen))
+	for currency := range seen {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// source returns the posting debiting the Wise balance, which is always last
+func (e Entry) source() Posting {
+	return e.Postings[len(e.Postings)-1]
+}
+
+// round rounds to cents so postings balance exactly in every tool
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,68 @@
+package accounting
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTransferEntryBalances(t *testing.T) {
+	date := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		source    Amount
+		target    Amount
+		fee       float64
+		rate      float64
+		postings  int
+		converted float64
+	}{
+		{"conversion", Amount{1000, "EUR"}, Amount{1093.21, "USD"}, 4.87, 1.0984, 3, 995.13},
+		{"conversion with fractional cents", Amount{100.005, "EUR"}, Amount{86.01, "GBP"}, 0.335, 0.8631, 3, 99.67},
+		{"conversion without fee", Amount{250, "GBP"}, Amount{289.5, "EUR"}, 0, 1.158, 2, 250},
+		{"same currency", Amount{500, "EUR"}, Amount{498.2, "EUR"}, 1.8, 1, 3, 498.2},
+		{"same currency with fractional cents", Amount{19.999, "EUR"}, Amount{19.5, "EUR"}, 0.494, 1, 3, 19.51},
+		{"same currency without fee", Amount{42.42, "USD"}, Amount{42.42, "USD"}, 0, 1, 2, 42.42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := TransferEntry(date, 1, "Payee", "Reference", tt.source, tt.target, tt.fee, tt.rate, "Expenses:Payee", "Expenses:Fees", "Assets:Wise")
+
+			if len(entry.Postings) != tt.postings {
+				t.Fatalf("got %d postings, want %d", len(entry.Postings), tt.postings)
+			}
+
+			var sum int64
+			for _, p := range entry.Postings {
+				value, currency := p.Amount.Value, p.Amount.Currency
+				if p.Price != nil {
+					value, currency = p.Price.Value, p.Price.Currency
+				}
+				if currency != tt.source.Currency {
+					t.Fatalf("posting %s is in %s, want it priced in %s", p.Account, currency, tt.source.Currency)
+				}
+				sum += int64(math.Round(value * 100))
+			}
+			if sum != 0 {
+				t.Errorf("postings sum to %d cents, want 0", sum)
+			}
+
+			payout := entry.Postings[0]
+			converted := payout.Amount.Value
+			if payout.Price != nil {
+				converted = payout.Price.Value
+				if payout.Amount != tt.target {
+					t.Errorf("payout amount = %v, want the target %v", payout.Amount, tt.target)
+				}
+			}
+			if converted != tt.converted {
+				t.Errorf("converted = %.2f, want %.2f", converted, tt.converted)
+			}
+			if got := entry.source().Amount.Value; got != -round(tt.source.Value) {
+				t.Errorf("source posting = %.2f, want %.2f", got, -round(tt.source.Value))
+			}
+		})
+	}
+}
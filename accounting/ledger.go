@@ -0,0 +1,107 @@
+package accounting
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ledgerDialect selects between the ledger and hledger flavours of the journal format
+type ledgerDialect int
+
+const (
+	ledgerStyle ledgerDialect = iota
+	hledgerStyle
+)
+
+// writeLedger writes entries as a ledger or hledger journal
+func writeLedger(w io.Writer, entries []Entry, dialect ledgerDialect) error {
+	for i, e := range entries {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		var b strings.Builder
+		payee := singleLine(e.Payee)
+		if dialect == hledgerStyle && e.Reference != "" {
+			// hledger splits the description into payee and note at the pipe
+			payee += " | " + singleLine(e.Reference)
+		}
+		fmt.Fprintf(&b, "%s * (%d) %s\n", e.Date.Format("2006-01-02"), e.TransferID, payee)
+
+		if dialect == hledgerStyle {
+			fmt.Fprintf(&b, "    ; transfer:%d, rate:%s\n", e.TransferID, formatRate(e.Rate))
+		} else {
+			if e.Reference != "" {
+				fmt.Fprintf(&b, "    ; Reference: %s\n", singleLine(e.Reference))
+			}
+			fmt.Fprintf(&b, "    ; Rate: %s\n", formatRate(e.Rate))
+		}
+
+		for _, p := range e.Postings {
+			fmt.Fprintf(&b, "    %-36s %s\n", p.Account, formatPosting(p))
+		}
+
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeBeancount writes entries as beancount transactions
+func writeBeancount(w io.Writer, entries []Entry) error {
+	for i, e := range entries {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s * %s %s\n", e.Date.Format("2006-01-02"), quote(e.Payee), quote(e.Reference))
+		fmt.Fprintf(&b, "  transfer: \"%d\"\n", e.TransferID)
+		fmt.Fprintf(&b, "  rate: %s\n", formatRate(e.Rate))
+
+		for _, p := range e.Postings {
+			fmt.Fprintf(&b, "  %-38s %s\n", p.Account, formatPosting(p))
+		}
+
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatPosting writes the amount of a posting, right-aligned, with its total price
+func formatPosting(p Posting) string {
+	amount := fmt.Sprintf("%16s", formatAmount(p.Amount))
+	if p.Price != nil {
+		amount += " @@ " + formatAmount(*p.Price)
+	}
+	return amount
+}
+
+// formatAmount writes an amount with two decimals followed by its commodity
+func formatAmount(a Amount) string {
+	return fmt.Sprintf("%.2f %s", a.Value, a.Currency)
+}
+
+// formatRate writes an exchange rate without trailing zeros
+func formatRate(rate float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.6f", rate), "0"), ".")
+}
+
+// singleLine collapses line breaks so free text cannot break the journal syntax
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// quote writes a beancount string literal
+func quote(s string) string {
+	s = strings.ReplaceAll(singleLine(s), `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,91 @@
+package accounting
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// writeQIF writes one QIF bank account section per source currency, with the
+// payout and fee as splits of each transaction
+func writeQIF(w io.Writer, entries []Entry) error {
+	var b strings.Builder
+	for _, currency := range SourceCurrencies(entries) {
+		var account string
+		for _, e := range entries {
+			if e.source().Amount.Currency == currency {
+				account = e.source().Account
+				break
+			}
+		}
+
+		fmt.Fprintf(&b, "!Account\nN%s\nTBank\n^\n!Type:Bank\n", singleLine(account))
+		for _, e := range entries {
+			source := e.source()
+			if source.Amount.Currency != currency {
+				continue
+			}
+
+			fmt.Fprintf(&b, "D%s\n", e.Date.Format("01/02/2006"))
+			fmt.Fprintf(&b, "T%.2f\n", source.Amount.Value)
+			fmt.Fprintf(&b, "N%d\n", e.TransferID)
+			fmt.Fprintf(&b, "P%s\n", singleLine(e.Payee))
+			if e.Reference != "" {
+				fmt.Fprintf(&b, "M%s\n", singleLine(e.Reference))
+			}
+			for _, p := range e.Postings[:len(e.Postings)-1] {
+				fmt.Fprintf(&b, "S%s\n", singleLine(p.Account))
+				if p.Memo != "" {
+					fmt.Fprintf(&b, "E%s\n", p.Memo)
+				}
+				fmt.Fprintf(&b, "$%.2f\n", -sourceValue(p))
+			}
+			b.WriteString("^\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeXeroCSV writes a Xero precoded bank statement import: one row for the
+// payout coded to the recipient's account and one for the fee
+func writeXeroCSV(w io.Writer, entries []Entry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"*Date", "*Amount", "Payee", "Description", "Reference", "Account Code"}); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		for _, p := range e.Postings[:len(e.Postings)-1] {
+			description := e.Reference
+			if p.Memo != "" {
+				description = p.Memo
+			}
+			record := []string{
+				e.Date.Format("02/01/2006"),
+				strconv.FormatFloat(-sourceValue(p), 'f', 2, 64),
+				singleLine(e.Payee),
+				singleLine(description),
+				strconv.Itoa(e.TransferID),
+				p.Account,
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// sourceValue returns what a posting cost in the source currency
+func sourceValue(p Posting) float64 {
+	if p.Price != nil {
+		return p.Price.Value
+	}
+	return p.Amount.Value
+}
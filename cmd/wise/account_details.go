@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/spf13/cobra"
+)
+
+// accountDetailsEntry is the bank details of one currency as written by --format json
+type accountDetailsEntry struct {
+	Currency       string                `json:"currency"`
+	Name           string                `json:"name"`
+	ReceiveOptions []receiveOptionsEntry `json:"receiveOptions"`
+}
+
+// receiveOptionsEntry maps detail types such as IBAN or ACCOUNT_HOLDER to their values
+type receiveOptionsEntry struct {
+	Type    string            `json:"type"`
+	Title   string            `json:"title"`
+	Details map[string]string `json:"details"`
+}
+
+var accountDetailsCmd = &cobra.Command{
+	Use:   "account-details",
+	Short: "Show the bank details for receiving money",
+	Long: `Show the bank details customers pay the profile with, such as the IBAN, account number and routing
+details, for each currency with active account details, or only one with --currency.
+
+With --format json the details are written for invoice templates, keyed by Wise's detail types such as
+ACCOUNT_HOLDER, IBAN, BIC, ACCOUNT_NUMBER and ROUTING_NUMBER. With --format epc the EUR details are
+written as an EPC QR payload (the "Girocode" banking apps scan to prefill a SEPA transfer), optionally
+with --amount and --reference; pass it to any QR code generator.
+
+  wise account-details --currency USD
+  wise account-details --format json -o bank-details.json
+  wise account-details --format epc --amount 1200 --reference "Invoice 2026-042" | qrencode -o invoice-qr.png`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		currency, _ := cmd.Flags().GetString("currency")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		amount, _ := cmd.Flags().GetFloat64("amount")
+		reference, _ := cmd.Flags().GetString("reference")
+
+		currency = strings.ToUpper(currency)
+		if format != "text" && format != "json" && format != "epc" {
+			return fmt.Errorf("unsupported format %q: use text, json or epc", format)
+		}
+		if format == "epc" {
+			if currency == "" {
+				currency = "EUR"
+			}
+			if currency != "EUR" {
+				return fmt.Errorf("EPC QR payloads are for SEPA payments in EUR only")
+			}
+		} else if amount != 0 || reference != "" {
+			return fmt.Errorf("--amount and --reference are only used with --format epc")
+		}
+
+		profileID, err := resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		all, err := queries.ListAccountDetailsWithRefresh(apiToken, profileID, refresh)
+		if err != nil {
+			return err
+		}
+
+		details := queries.ActiveAccountDetails(all, currency)
+		if len(details) == 0 {
+			if currency == "" {
+				return fmt.Errorf("the profile has no active account details")
+			}
+			return fmt.Errorf("no active %s account details: request them in Wise first", currency)
+		}
+
+		var payload string
+		if format == "epc" {
+			payload, err = epcPayload(details[0], amount, reference)
+			if err != nil {
+				return err
+			}
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		switch format {
+		case "json":
+			err = writeAccountDetailsJSON(w, details)
+		case "epc":
+			_, err = fmt.Fprintln(w, payload)
+		default:
+			writeAccountDetailsText(w, details)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write account details: %w", err)
+		}
+
+		if output != "" {
+			fmt.Printf("✓ Wrote account details to %s\n", output)
+		}
+		return nil
+	},
+}
+
+// writeAccountDetailsText prints each currency's receive options with their
+// details, indenting the continuation lines of multi-line values such as addresses
+func writeAccountDetailsText(w io.Writer, details []queries.AccountDetails) {
+	for i, d := range details {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		title := d.Title
+		if title == "" {
+			title = d.Currency.Name
+		}
+		fmt.Fprintf(w, "%s — %s\n", d.Currency.Code, title)
+		fmt.Fprintln(w, strings.Repeat("=", 60))
+
+		for _, option := range d.ReceiveOptions {
+			fmt.Fprintf(w, "\n%s\n", orDash(option.Title))
+			for _, detail := range option.Details {
+				if detail.Hidden || detail.Body == "" {
+					continue
+				}
+				lines := strings.Split(strings.TrimSpace(detail.Body), "\n")
+				fmt.Fprintf(w, "  %-24s %s\n", detail.Title+":", lines[0])
+				for _, line := range lines[1:] {
+					fmt.Fprintf(w, "  %-24s %s\n", "", strings.TrimSpace(line))
+				}
+			}
+		}
+	}
+}
+
+// writeAccountDetailsJSON writes the details keyed by detail type, leaving out hidden ones
+func writeAccountDetailsJSON(w io.Writer, details []queries.AccountDetails) error {
+	entries := make([]accountDetailsEntry, 0, len(details))
+	for _, d := range details {
+		entry := accountDetailsEntry{
+			Currency:       d.Currency.Code,
+			Name:           d.Currency.Name,
+			ReceiveOptions: []receiveOptionsEntry{},
+		}
+		for _, option := range d.ReceiveOptions {
+			values := make(map[string]string)
+			for _, detail := range option.Details {
+				if detail.Hidden || detail.Body == "" {
+					continue
+				}
+				if _, ok := values[detail.Type]; !ok {
+					values[detail.Type] = detail.Body
+				}
+			}
+			entry.ReceiveOptions = append(entry.ReceiveOptions, receiveOptionsEntry{
+				Type:    option.Type,
+				Title:   option.Title,
+				Details: values,
+			})
+		}
+		entries = append(entries, entry)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// epcPayload builds the EPC QR code payload (EPC069-12, version 002) for a
+// SEPA credit transfer to the IBAN of the EUR account details. The amount is
+// left out when it is 0 and the reference is sent as unstructured remittance text.
+func epcPayload(details queries.AccountDetails, amount float64, reference string) (string, error) {
+	var name, iban, bic string
+	for _, option := range details.ReceiveOptions {
+		if value := option.Value("IBAN"); value != "" {
+			iban = value
+			name = option.Value("ACCOUNT_HOLDER")
+			bic = option.Value("BIC", "SWIFT_CODE")
+			break
+		}
+	}
+	if iban == "" {
+		return "", fmt.Errorf("the %s account details have no IBAN", details.Currency.Code)
+	}
+
+	iban = strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+	bic = strings.ToUpper(strings.ReplaceAll(bic, " ", ""))
+	name = strings.TrimSpace(name)
+	reference = strings.TrimSpace(reference)
+
+	if name == "" {
+		return "", fmt.Errorf("the %s account details have no account holder", details.Currency.Code)
+	}
+	if len([]rune(name)) > 70 {
+		name = string([]rune(name)[:70])
+	}
+	if len([]rune(reference)) > 140 {
+		return "", fmt.Errorf("--reference is too long for an EPC QR code: at most 140 characters")
+	}
+
+	amountField := ""
+	if amount != 0 {
+		if amount < 0.01 || amount > 999999999.99 {
+			return "", fmt.Errorf("invalid amount %.2f: must be between 0.01 and 999999999.99 EUR", amount)
+		}
+		amountField = fmt.Sprintf("EUR%.2f", amount)
+	}
+
+	// Service tag, version, UTF-8, SEPA credit transfer, then beneficiary and
+	// remittance; the purpose and structured reference stay empty
+	lines := []string{"BCD", "002", "1", "SCT", bic, name, iban, amountField, "", "", reference}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n"), nil
+}
+
+func init() {
+	accountDetailsCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+	accountDetailsCmd.Flags().StringP("currency", "c", "", "Only show the details of this currency (optional)")
+	accountDetailsCmd.Flags().StringP("format", "f", "text", "Output format: text, json or epc (EPC QR payload for EUR)")
+	accountDetailsCmd.Flags().StringP("output", "o", "", "Write to a file instead of stdout (optional)")
+	accountDetailsCmd.Flags().Float64("amount", 0, "Amount in EUR to prefill in the EPC QR payload (optional)")
+	accountDetailsCmd.Flags().String("reference", "", "Reference to prefill in the EPC QR payload, e.g. the invoice number (optional)")
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/spf13/cobra"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage recipient aliases",
+	Long:  "Manage a local address book of short names for Wise recipients",
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set <name> <recipient-id>",
+	Short: "Create or update an alias",
+	Long:  "Point an alias at a recipient ID, optionally with default currency, reference and source account for send-to",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		recipientID, err := strconv.Atoi(args[1])
+		if err != nil || recipientID <= 0 {
+			return fmt.Errorf("invalid recipient ID: %s", args[1])
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		currency, _ := cmd.Flags().GetString("currency")
+		reference, _ := cmd.Flags().GetString("reference")
+		sourceAccount, _ := cmd.Flags().GetInt("source-account")
+
+		alias := config.Alias{
+			Name:          name,
+			RecipientID:   recipientID,
+			Currency:      currency,
+			Reference:     reference,
+			SourceAccount: sourceAccount,
+		}
+
+		// Verify the recipient exists when we are able to ask the API
+		recipientName := ""
+		if apiToken != "" {
+			recipient, err := findRecipientByID(profileID, recipientID)
+			if err != nil {
+				return err
+			}
+			if recipient == nil {
+				return fmt.Errorf("recipient not found: %d", recipientID)
+			}
+			recipientName = recipient.Name.FullName
+			if alias.Currency == "" {
+				alias.Currency = recipient.Currency
+			}
+		}
+
+		if err := config.SetAlias(alias); err != nil {
+			return fmt.Errorf("failed to save alias: %w", err)
+		}
+
+		if recipientName != "" {
+			fmt.Printf("✓ Alias %s → %s (ID: %d)\n", alias.Name, recipientName, alias.RecipientID)
+		} else {
+			fmt.Printf("✓ Alias %s → recipient %d\n", alias.Name, alias.RecipientID)
+		}
+		return nil
+	},
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List aliases",
+	Long:  "List all aliases in the local address book",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		aliases, err := config.SortedAliases()
+		if err != nil {
+			return fmt.Errorf("failed to load aliases: %w", err)
+		}
+
+		if len(aliases) == 0 {
+			fmt.Println("No aliases found")
+			return nil
+		}
+
+		// Format output
+		fmt.Printf("%-20s %-12s %-10s %-15s %-30s\n", "Alias", "Recipient", "Currency", "Source Account", "Reference")
+		fmt.Println(strings.Repeat("-", 90))
+
+		for _, a := range aliases {
+			currency := a.Currency
+			if currency == "" {
+				currency = "-"
+			}
+			sourceAccount := "-"
+			if a.SourceAccount != 0 {
+				sourceAccount = strconv.Itoa(a.SourceAccount)
+			}
+			reference := a.Reference
+			if reference == "" {
+				reference = "-"
+			}
+			fmt.Printf("%-20s %-12d %-10s %-15s %-30s\n",
+				a.Name,
+				a.RecipientID,
+				currency,
+				sourceAccount,
+				reference,
+			)
+		}
+
+		return nil
+	},
+}
+
+var aliasRmCmd = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Remove an alias",
+	Long:  "Remove an alias from the local address book",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.RemoveAlias(args[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Removed alias %s\n", args[0])
+		return nil
+	},
+}
+
+// findRecipientByID looks up a recipient by ID, returning nil if it does not exist
+func findRecipientByID(profileID, recipientID int) (*queries.Recipient, error) {
+	if profileID == 0 {
+		defaultProfile, err := config.LoadDefaultProfile()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load default profile: %w", err)
+		}
+		profileID = defaultProfile
+	}
+
+	recipients, err := queries.ListRecipientsWithRefresh(apiToken, queries.ListRecipientsRequest{
+		ProfileID: profileID,
+	}, refresh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recipients: %w", err)
+	}
+
+	for i := range recipients {
+		if recipients[i].ID == recipientID {
+			return &recipients[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasRmCmd)
+
+	aliasSetCmd.Flags().IntP("profile-id", "p", 0, "Profile ID used to verify the recipient (optional, uses default if not set)")
+	aliasSetCmd.Flags().StringP("currency", "c", "", "Default currency for send-to (optional, defaults to the recipient currency)")
+	aliasSetCmd.Flags().StringP("reference", "r", "", "Default payment reference for send-to (optional)")
+	aliasSetCmd.Flags().IntP("source-account", "s", 0, "Default source account ID for send-to (optional)")
+}
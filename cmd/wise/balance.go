@@ -0,0 +1,417 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dhamidi/wise-cli/commands"
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var balanceCmd = &cobra.Command{
+	Use:   "balance",
+	Short: "Manage currency balances",
+	Long:  "List, open and close the currency balances of a profile",
+}
+
+var balanceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List balances and jars",
+	Long:  "List the currency balances and savings jars of a profile with their IDs and amounts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		profileID, err := resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		balances, err := listAllBalances(profileID, refresh)
+		if err != nil {
+			return err
+		}
+
+		printBalances(balances, "No balances found")
+		return nil
+	},
+}
+
+var balanceOpenCmd = &cobra.Command{
+	Use:   "open <currency>",
+	Short: "Open a currency balance",
+	Long:  "Open a balance in a currency so the profile can hold, convert to and receive it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		currency := strings.ToUpper(args[0])
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		profileID, err := resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		balances, err := listAllBalances(profileID, true)
+		if err != nil {
+			return err
+		}
+		if existing, err := findStandardBalance(balances, currency); err == nil {
+			return fmt.Errorf("the profile already has a balance in %s (ID: %d)", currency, existing.ID)
+		}
+
+		signer, err := signerFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		balance, err := commands.OpenBalance(apiToken, commands.OpenBalanceRequest{
+			ProfileID:      profileID,
+			Currency:       currency,
+			Type:           "STANDARD",
+			IdempotencyKey: uuid.New().String(),
+		}, signer)
+		if err != nil {
+			return err
+		}
+		invalidateBalances(profileID)
+
+		fmt.Printf("✓ Opened %s balance (ID: %d)\n", balance.Currency, balance.ID)
+		return nil
+	},
+}
+
+var balanceCloseCmd = &cobra.Command{
+	Use:   "close <balance-id>",
+	Short: "Close a balance or jar",
+	Long:  "Close an empty currency balance or savings jar. Move or convert what is left on it first.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		balanceID, err := strconv.Atoi(args[0])
+		if err != nil || balanceID <= 0 {
+			return fmt.Errorf("invalid balance ID: %s", args[0])
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		profileID, err = resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		balances, err := listAllBalances(profileID, true)
+		if err != nil {
+			return err
+		}
+		var balance *queries.Balance
+		for i := range balances {
+			if balances[i].ID == balanceID {
+				balance = &balances[i]
+				break
+			}
+		}
+		if balance == nil {
+			return fmt.Errorf("balance not found: %d (see 'wise balance list')", balanceID)
+		}
+		if balance.Amount.Value != 0 {
+			return fmt.Errorf("%s still holds %.2f %s: move or convert it before closing", balanceLabel(*balance), balance.Amount.Value, balance.Currency)
+		}
+
+		signer, err := signerFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		if err := commands.CloseBalance(apiToken, profileID, balanceID, signer); err != nil {
+			return err
+		}
+		invalidateBalances(profileID)
+
+		fmt.Printf("✓ Closed %s (ID: %d)\n", balanceLabel(*balance), balanceID)
+		return nil
+	},
+}
+
+var jarCmd = &cobra.Command{
+	Use:   "jar",
+	Short: "Manage savings jars",
+	Long:  "Create savings jars and move money between them and the balance of the same currency",
+}
+
+var jarListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List jars",
+	Long:  "List the savings jars of a profile with their IDs and amounts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		profileID, err := resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		balances, err := listAllBalances(profileID, refresh)
+		if err != nil {
+			return err
+		}
+
+		var jars []queries.Balance
+		for _, b := range balances {
+			if b.Type == "SAVINGS" {
+				jars = append(jars, b)
+			}
+		}
+
+		printBalances(jars, "No jars found")
+		return nil
+	},
+}
+
+var jarCreateCmd = &cobra.Command{
+	Use:   "create <name> <currency>",
+	Short: "Create a savings jar",
+	Long:  "Create a savings jar holding one currency, e.g. to put money aside for taxes",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		name := strings.TrimSpace(args[0])
+		currency := strings.ToUpper(args[1])
+		if name == "" {
+			return fmt.Errorf("jar name cannot be empty")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		profileID, err := resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		balances, err := listAllBalances(profileID, true)
+		if err != nil {
+			return err
+		}
+		if existing, err := findJar(balances, name); err == nil {
+			return fmt.Errorf("a jar named %q already exists (ID: %d)", name, existing.ID)
+		}
+
+		signer, err := signerFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		jar, err := commands.OpenBalance(apiToken, commands.OpenBalanceRequest{
+			ProfileID:      profileID,
+			Currency:       currency,
+			Type:           "SAVINGS",
+			Name:           name,
+			IdempotencyKey: uuid.New().String(),
+		}, signer)
+		if err != nil {
+			return err
+		}
+		invalidateBalances(profileID)
+
+		fmt.Printf("✓ Created jar %q in %s (ID: %d)\n", name, jar.Currency, jar.ID)
+		return nil
+	},
+}
+
+var jarMoveCmd = &cobra.Command{
+	Use:   "move <amount> <currency>",
+	Short: "Move money into or out of a jar",
+	Long:  "Move money from the balance of a currency into a jar with --to, or from a jar back to the balance with --from. Jars are given by name or ID; the jar must hold the same currency.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		amount, err := strconv.ParseFloat(args[0], 64)
+		if err != nil || amount <= 0 {
+			return fmt.Errorf("invalid amount %q: must be greater than 0", args[0])
+		}
+		currency := strings.ToUpper(args[1])
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		to, _ := cmd.Flags().GetString("to")
+		from, _ := cmd.Flags().GetString("from")
+
+		if (to == "") == (from == "") {
+			return fmt.Errorf("exactly one of --to or --from is required")
+		}
+
+		profileID, err = resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		balances, err := listAllBalances(profileID, true)
+		if err != nil {
+			return err
+		}
+		balance, err := findStandardBalance(balances, currency)
+		if err != nil {
+			return err
+		}
+		jar, err := findJar(balances, to+from)
+		if err != nil {
+			return err
+		}
+		if jar.Currency != currency {
+			return fmt.Errorf("jar %q holds %s, not %s", balanceLabel(*jar), jar.Currency, currency)
+		}
+
+		source, target := balance, jar
+		if from != "" {
+			source, target = jar, balance
+		}
+		if amount > source.Amount.Value {
+			return fmt.Errorf("insufficient funds in %s: %.2f %s available", balanceLabel(*source), source.Amount.Value, currency)
+		}
+
+		signer, err := signerFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		movement, err := commands.MoveBalance(apiToken, commands.MoveBalanceRequest{
+			ProfileID:       profileID,
+			SourceBalanceID: source.ID,
+			TargetBalanceID: target.ID,
+			Amount:          commands.Amount{Value: amount, Currency: currency},
+			IdempotencyKey:  uuid.New().String(),
+		}, signer)
+		if err != nil {
+			return err
+		}
+		invalidateBalances(profileID)
+
+		fmt.Printf("✓ Moved %.2f %s from %s to %s\n", amount, currency, balanceLabel(*source), balanceLabel(*target))
+		for _, after := range movement.BalancesAfter {
+			label := fmt.Sprintf("%d", after.ID)
+			switch after.ID {
+			case source.ID:
+				label = balanceLabel(*source)
+			case target.ID:
+				label = balanceLabel(*target)
+			}
+			fmt.Printf("  %-30s %15.2f %s\n", label, after.Value, after.Currency)
+		}
+		return nil
+	},
+}
+
+// listAllBalances fetches the currency balances and savings jars of a profile
+func listAllBalances(profileID int, refresh bool) ([]queries.Balance, error) {
+	balances, err := queries.ListBalancesWithRefresh(apiToken, queries.ListBalancesRequest{
+		ProfileID: profileID,
+		Types:     []string{"STANDARD", "SAVINGS"},
+	}, refresh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list balances: %w", err)
+	}
+	return balances, nil
+}
+
+// findStandardBalance returns the currency balance, as opposed to a jar, of a currency
+func findStandardBalance(balances []queries.Balance, currency string) (*queries.Balance, error) {
+	var standard []queries.Balance
+	for _, b := range balances {
+		if b.Type != "SAVINGS" {
+			standard = append(standard, b)
+		}
+	}
+	return queries.FindBalance(standard, currency)
+}
+
+// findJar returns the savings jar with the given ID or name (case-insensitive)
+func findJar(balances []queries.Balance, nameOrID string) (*queries.Balance, error) {
+	id, _ := strconv.Atoi(nameOrID)
+	for i := range balances {
+		b := &balances[i]
+		if b.Type != "SAVINGS" {
+			continue
+		}
+		if b.ID == id || (b.Name != nil && strings.EqualFold(*b.Name, nameOrID)) {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("jar not found: %s (see 'wise jar list')", nameOrID)
+}
+
+// balanceLabel names a balance for output, such as "EUR balance" or "jar Tax reserve"
+func balanceLabel(b queries.Balance) string {
+	if b.Type == "SAVINGS" {
+		if b.Name != nil && *b.Name != "" {
+			return "jar " + *b.Name
+		}
+		return fmt.Sprintf("%s jar %d", b.Currency, b.ID)
+	}
+	return b.Currency + " balance"
+}
+
+// printBalances prints balances and jars as a table
+func printBalances(balances []queries.Balance, empty string) {
+	if len(balances) == 0 {
+		fmt.Println(empty)
+		return
+	}
+
+	fmt.Printf("%-12s %-9s %-8s %-25s %15s\n", "ID", "Type", "Currency", "Name", "Amount")
+	fmt.Println(strings.Repeat("-", 73))
+
+	for _, b := range balances {
+		name := "-"
+		if b.Name != nil && *b.Name != "" {
+			name = *b.Name
+		}
+		fmt.Printf("%-12d %-9s %-8s %-25s %15.2f\n", b.ID, b.Type, b.Currency, name, b.Amount.Value)
+	}
+}
+
+// invalidateBalances drops cached balance lists of a profile after balances changed
+func invalidateBalances(profileID int) {
+	if err := config.ClearCacheEntries(fmt.Sprintf("balances-%d-", profileID)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to clear balance cache: %v\n", err)
+	}
+}
+
+func init() {
+	balanceCmd.AddCommand(balanceListCmd)
+	balanceCmd.AddCommand(balanceOpenCmd)
+	balanceCmd.AddCommand(balanceCloseCmd)
+
+	jarCmd.AddCommand(jarListCmd)
+	jarCmd.AddCommand(jarCreateCmd)
+	jarCmd.AddCommand(jarMoveCmd)
+
+	for _, c := range []*cobra.Command{balanceListCmd, balanceOpenCmd, balanceCloseCmd, jarListCmd, jarCreateCmd, jarMoveCmd} {
+		c.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+	}
+	for _, c := range []*cobra.Command{balanceOpenCmd, balanceCloseCmd, jarCreateCmd, jarMoveCmd} {
+		c.Flags().String("private-key", "", privateKeyUsage)
+	}
+
+	jarMoveCmd.Flags().String("to", "", "Jar to move the money into, by name or ID")
+	jarMoveCmd.Flags().String("from", "", "Jar to move the money out of, back to the balance, by name or ID")
+}
@@ -2,14 +2,20 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dhamidi/wise-cli/commands"
 	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/filter"
+	"github.com/dhamidi/wise-cli/paymentref"
 	"github.com/dhamidi/wise-cli/queries"
+	"github.com/dhamidi/wise-cli/validation"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
@@ -36,16 +42,31 @@ func main() {
 
 	rootCmd.PersistentFlags().StringVar(&apiToken, "token", tokenDefault, "Wise API token (or set WISE_API_TOKEN env var)")
 	rootCmd.PersistentFlags().BoolVar(&refresh, "refresh", false, "Force refresh cache, bypass cached responses")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Answer from the local store only, without syncing (see 'wise sync')")
 
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(meCmd)
 	rootCmd.AddCommand(profilesCmd)
 	rootCmd.AddCommand(selectProfileCmd)
 	rootCmd.AddCommand(recipientsCmd)
+	rootCmd.AddCommand(recipientCmd)
 	rootCmd.AddCommand(quoteCmd)
+	rootCmd.AddCommand(rateCmd)
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(sendToCmd)
+	rootCmd.AddCommand(ordersCmd)
+	rootCmd.AddCommand(quotesCmd)
+	rootCmd.AddCommand(convertCmd)
+	rootCmd.AddCommand(balanceCmd)
+	rootCmd.AddCommand(jarCmd)
+	rootCmd.AddCommand(accountDetailsCmd)
 	rootCmd.AddCommand(transfersCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(aliasCmd)
+	rootCmd.AddCommand(scheduleCmd)
+	rootCmd.AddCommand(statementCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(scaCmd)
 	rootCmd.AddCommand(agentsCmd)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -243,16 +264,27 @@ var recipientsCmd = &cobra.Command{
 		typeFilter, _ := cmd.Flags().GetString("type")
 		size, _ := cmd.Flags().GetInt("size")
 
-		req := queries.ListRecipientsRequest{
-			ProfileID: profileID,
-			Currency:  currency,
-			Type:      typeFilter,
-			Size:      size,
-		}
+		var recipients []queries.Recipient
+		if _, local := localProfileIDs(profileID); local || offline {
+			// Synced recipients are filtered locally
+			stored, err := listRecipients(profileID)
+			if err != nil {
+				return fmt.Errorf("failed to list recipients: %w", err)
+			}
+			recipients = filterRecipients(stored, currency, typeFilter)
+		} else {
+			req := queries.ListRecipientsRequest{
+				ProfileID: profileID,
+				Currency:  currency,
+				Type:      typeFilter,
+				Size:      size,
+			}
 
-		recipients, err := queries.ListRecipientsWithRefresh(apiToken, req, refresh)
-		if err != nil {
-			return fmt.Errorf("failed to list recipients: %w", err)
+			var err error
+			recipients, err = queries.ListRecipientsWithRefresh(apiToken, req, refresh)
+			if err != nil {
+				return fmt.Errorf("failed to list recipients: %w", err)
+			}
 		}
 
 		if len(recipients) == 0 {
@@ -296,7 +328,7 @@ var newCmd = &cobra.Command{
 var newTransferCmd = &cobra.Command{
 	Use:   "transfer",
 	Short: "Create transfer",
-	Long:  "Create a transfer based on a quote",
+	Long:  "Create a transfer based on a quote. A quote created by the CLI that has expired is refreshed first by updating it with the target account, unless --no-refresh is set.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if apiToken == "" {
 			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
@@ -307,6 +339,8 @@ var newTransferCmd = &cobra.Command{
 		customerTransactionID, _ := cmd.Flags().GetString("customer-transaction-id")
 		reference, _ := cmd.Flags().GetString("reference")
 		sourceAccount, _ := cmd.Flags().GetInt("source-account")
+		noRefresh, _ := cmd.Flags().GetBool("no-refresh")
+		detailPairs, _ := cmd.Flags().GetStringArray("detail")
 
 		if targetAccount == 0 {
 			return fmt.Errorf("target-account is required")
@@ -318,10 +352,36 @@ var newTransferCmd = &cobra.Command{
 			return fmt.Errorf("customer-transaction-id is required")
 		}
 
+		values, err := parseKeyValues(detailPairs)
+		if err != nil {
+			return err
+		}
+
+		if err := refreshExpiredQuote(quoteUUID, targetAccount, !noRefresh); err != nil {
+			return err
+		}
+
+		// Check the reference against the payout currency rules before anything is created
+		if reference != "" {
+			targetCurrency, err := transferTargetCurrency(quoteUUID, targetAccount)
+			if err != nil {
+				return err
+			}
+			if err := checkReference(reference, targetCurrency); err != nil {
+				return fmt.Errorf("invalid reference: %w", err)
+			}
+		}
+
+		details, err := collectTransferDetails(quoteUUID, targetAccount, reference, values)
+		if err != nil {
+			return err
+		}
+
 		req := commands.NewTransferRequest{
 			TargetAccount:         targetAccount,
 			QuoteUUID:             quoteUUID,
 			CustomerTransactionID: customerTransactionID,
+			Details:               details,
 		}
 
 		if reference != "" {
@@ -335,6 +395,7 @@ var newTransferCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create transfer: %w", err)
 		}
+		markQuoteUsed(quoteUUID, transfer.ID, 0)
 
 		// Format output
 		fmt.Println("Transfer Created:")
@@ -370,7 +431,7 @@ var newTransferCmd = &cobra.Command{
 var newQuoteCmd = &cobra.Command{
 	Use:   "quote",
 	Short: "Create authenticated quote",
-	Long:  "Create an authenticated quote for a currency conversion",
+	Long:  "Create an authenticated quote for a currency conversion. With --recipient, the quote is updated with the recipient so that its fees, rate and payment options are those of paying that recipient.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if apiToken == "" {
 			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
@@ -381,6 +442,8 @@ var newQuoteCmd = &cobra.Command{
 		targetCurrency, _ := cmd.Flags().GetString("target-currency")
 		sourceAmount, _ := cmd.Flags().GetFloat64("source-amount")
 		targetAmount, _ := cmd.Flags().GetFloat64("target-amount")
+		payIn, _ := cmd.Flags().GetString("pay-in")
+		recipientArg, _ := cmd.Flags().GetString("recipient")
 
 		if profileID == 0 {
 			return fmt.Errorf("profile-id is required")
@@ -402,6 +465,7 @@ var newQuoteCmd = &cobra.Command{
 			ProfileID:      profileID,
 			SourceCurrency: sourceCurrency,
 			TargetCurrency: targetCurrency,
+			PayIn:          strings.ToUpper(payIn),
 		}
 
 		if sourceAmount != 0 {
@@ -411,14 +475,35 @@ var newQuoteCmd = &cobra.Command{
 			req.TargetAmount = &targetAmount
 		}
 
+		// Look up the recipient first, so a typo does not leave a quote behind
+		var recipient *queries.Recipient
+		if recipientArg != "" {
+			var err error
+			recipient, err = findQuoteRecipient(profileID, recipientArg, targetCurrency)
+			if err != nil {
+				return err
+			}
+		}
+
 		quote, err := commands.NewQuote(apiToken, req)
 		if err != nil {
 			return fmt.Errorf("failed to create quote: %w", err)
 		}
+		rememberQuote(quote, profileID, req.PayIn, 0)
+
+		if recipient != nil {
+			fmt.Printf("Quote created: %s\n", quote.ID)
+			quote, err = attachRecipient(quote, profileID, recipient.ID, req.PayIn)
+			if err != nil {
+				return err
+			}
+			fmt.Println()
+		}
 
 		// Format output
 		fmt.Println("Quote Details:")
 		fmt.Println("==============")
+		fmt.Printf("Quote ID:           %s\n", quote.ID)
 		fmt.Printf("Source:             %.2f %s\n", quote.SourceAmount, quote.SourceCurrency)
 		fmt.Printf("Target:             %.2f %s\n", quote.TargetAmount, quote.TargetCurrency)
 		fmt.Printf("Exchange Rate:      %.6f\n", quote.Rate)
@@ -621,75 +706,102 @@ var newRecipientCmd = &cobra.Command{
 
 		// Build details map based on recipient type and currency
 		details := make(map[string]interface{})
-
-		// Parse currency-specific details from flags
-		switch recipientType {
-		case "sort_code":
-			// GBP sort code recipient
-			sortCode, _ := cmd.Flags().GetString("sort-code")
-			accountNumber, _ := cmd.Flags().GetString("account-number")
-			legalType, _ := cmd.Flags().GetString("legal-type")
-
-			if sortCode == "" {
-				return fmt.Errorf("sort-code is required for sort_code type")
-			}
-			if accountNumber == "" {
-				return fmt.Errorf("account-number is required for sort_code type")
+		fieldPairs, _ := cmd.Flags().GetStringArray("field")
+
+		if len(fieldPairs) > 0 || !isBuiltinRecipientType(recipientType) {
+			// Any other type is driven by the account requirements Wise publishes for the currency
+			sourceCurrency, _ := cmd.Flags().GetString("source-currency")
+			sourceAmount, _ := cmd.Flags().GetFloat64("source-amount")
+			if sourceCurrency == "" {
+				sourceCurrency = currency
 			}
 
-			details["sortCode"] = sortCode
-			details["accountNumber"] = accountNumber
-			if legalType != "" {
-				details["legalType"] = legalType
+			var err error
+			details, err = collectAccountDetails(profileID, sourceCurrency, currency, sourceAmount, recipientType, accountHolderName, fieldPairs)
+			if err != nil {
+				return err
 			}
+		} else {
+			// Parse currency-specific details from flags
+			switch recipientType {
+			case "sort_code":
+				// GBP sort code recipient
+				sortCode, _ := cmd.Flags().GetString("sort-code")
+				accountNumber, _ := cmd.Flags().GetString("account-number")
+				legalType, _ := cmd.Flags().GetString("legal-type")
+
+				if sortCode == "" {
+					return fmt.Errorf("sort-code is required for sort_code type")
+				}
+				if accountNumber == "" {
+					return fmt.Errorf("account-number is required for sort_code type")
+				}
 
-		case "iban":
-			// IBAN recipient
-			iban, _ := cmd.Flags().GetString("iban")
-			legalType, _ := cmd.Flags().GetString("legal-type")
+				details["sortCode"] = sortCode
+				details["accountNumber"] = accountNumber
+				if legalType != "" {
+					details["legalType"] = legalType
+				}
 
-			if iban == "" {
-				return fmt.Errorf("iban is required for iban type")
-			}
+			case "iban":
+				// IBAN recipient
+				iban, _ := cmd.Flags().GetString("iban")
+				legalType, _ := cmd.Flags().GetString("legal-type")
 
-			details["iban"] = iban
-			if legalType != "" {
-				details["legalType"] = legalType
-			}
+				if iban == "" {
+					return fmt.Errorf("iban is required for iban type")
+				}
 
-		case "us":
-			// USD recipient
-			routingNumber, _ := cmd.Flags().GetString("routing-number")
-			accountNumber, _ := cmd.Flags().GetString("account-number")
-			accountType, _ := cmd.Flags().GetString("account-type")
-			legalType, _ := cmd.Flags().GetString("legal-type")
+				details["iban"] = iban
+				if legalType != "" {
+					details["legalType"] = legalType
+				}
 
-			if routingNumber == "" {
-				return fmt.Errorf("routing-number is required for us type")
-			}
-			if accountNumber == "" {
-				return fmt.Errorf("account-number is required for us type")
-			}
-			if accountType == "" {
-				return fmt.Errorf("account-type is required for us type")
-			}
+			case "us":
+				// USD recipient
+				routingNumber, _ := cmd.Flags().GetString("routing-number")
+				accountNumber, _ := cmd.Flags().GetString("account-number")
+				accountType, _ := cmd.Flags().GetString("account-type")
+				legalType, _ := cmd.Flags().GetString("legal-type")
 
-			details["routingNumber"] = routingNumber
-			details["accountNumber"] = accountNumber
-			details["accountType"] = accountType
-			if legalType != "" {
-				details["legalType"] = legalType
-			}
+				if routingNumber == "" {
+					return fmt.Errorf("routing-number is required for us type")
+				}
+				if accountNumber == "" {
+					return fmt.Errorf("account-number is required for us type")
+				}
+				if accountType == "" {
+					return fmt.Errorf("account-type is required for us type")
+				}
 
-		case "email":
-			// Email recipient
-			email, _ := cmd.Flags().GetString("email")
+				details["routingNumber"] = routingNumber
+				details["accountNumber"] = accountNumber
+				details["accountType"] = accountType
+				if legalType != "" {
+					details["legalType"] = legalType
+				}
+
+			case "email":
+				// Email recipient
+				email, _ := cmd.Flags().GetString("email")
+
+				if email == "" {
+					return fmt.Errorf("email is required for email type")
+				}
 
-			if email == "" {
-				return fmt.Errorf("email is required for email type")
+				details["email"] = email
 			}
+		}
+
+		// Catch typos in bank details locally before the API sees them
+		if err := validation.RecipientDetails(details); err != nil {
+			return fmt.Errorf("invalid recipient details: %w", err)
+		}
 
-			details["email"] = email
+		// Refuse to create a second recipient for an account that already has one
+		allowDuplicate, _ := cmd.Flags().GetBool("allow-duplicate")
+		if err := checkDuplicateRecipient(profileID, currency, details, allowDuplicate); err != nil {
+			return err
 		}
 
 		req := commands.NewRecipientRequest{
@@ -705,6 +817,8 @@ var newRecipientCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create recipient: %w", err)
 		}
+		invalidateRecipients()
+		recordRecipientCreated(recipient.ID)
 
 		// Format output
 		fmt.Println("Recipient Created:")
@@ -724,11 +838,24 @@ var newRecipientCmd = &cobra.Command{
 	},
 }
 
+// errEnoughTransfers stops listing transfers once --limit matches were printed
+var errEnoughTransfers = errors.New("enough transfers")
+
 var transfersCmd = &cobra.Command{
-	Use:   "transfers [search-term]",
+	Use:   "transfers [search-term-or-filter]",
 	Short: "List transfers",
-	Long:  "Fetch a list of your transfers from Wise (defaults to last 30 days). Optional search-term matches both recipient name and reference (substring, case-insensitive).",
-	Args:  cobra.RangeArgs(0, 1),
+	Long: `Fetch a list of your transfers from Wise (defaults to last 30 days, or use --since/--until).
+
+A plain search-term matches both recipient name and reference (substring, case-insensitive).
+An argument starting with a field name and an operator is a filter expression instead, which selects
+transfers by field, for example:
+
+  wise transfers 'amount>500 and currency:EUR and recipient~"acme" and created>=2026-01-01'
+
+Operators are : and = (equal), != (not equal), ~ (contains), >, >=, <, <=, combined with and, or, not
+and parentheses. Fields: ` + strings.Join(filter.FieldNames(transferFields), ", ") + `.
+amount and currency refer to what the recipient receives.`,
+	Args: cobra.RangeArgs(0, 1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if apiToken == "" {
 			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
@@ -736,50 +863,58 @@ var transfersCmd = &cobra.Command{
 
 		profileID, _ := cmd.Flags().GetInt("profile-id")
 		status, _ := cmd.Flags().GetString("status")
-		days, _ := cmd.Flags().GetInt("days")
+		limit, _ := cmd.Flags().GetInt("limit")
 		recipientFilter, _ := cmd.Flags().GetString("recipient")
 		referenceFilter, _ := cmd.Flags().GetString("reference")
+		sortSpec, _ := cmd.Flags().GetString("sort")
+		columnSpec, _ := cmd.Flags().GetString("columns")
 
-		// If search term provided as positional arg, use it for both recipient and reference
+		// A filter expression is evaluated per transfer, a plain search term
+		// matches both recipient and reference
+		var expr filter.Expr
 		if len(args) > 0 {
-			searchTerm := args[0]
-			if recipientFilter == "" {
-				recipientFilter = searchTerm
-			}
-			if referenceFilter == "" {
-				referenceFilter = searchTerm
+			if filter.IsExpression(args[0], transferFields) {
+				var err error
+				expr, err = filter.Parse(args[0], transferFields)
+				if err != nil {
+					return fmt.Errorf("invalid filter: %w", err)
+				}
+			} else {
+				searchTerm := args[0]
+				if recipientFilter == "" {
+					recipientFilter = searchTerm
+				}
+				if referenceFilter == "" {
+					referenceFilter = searchTerm
+				}
 			}
 		}
 
-		// Calculate since date (default 30 days ago)
-		until := time.Now()
-		since := until.AddDate(0, 0, -days)
+		sortKeys, err := filter.ParseSort(sortSpec, transferFields)
+		if err != nil {
+			return err
+		}
 
-		req := queries.ListTransfersRequest{
-			ProfileID: profileID,
-			Status:    status,
-			Since:     &since,
-			Until:     &until,
-			Limit:     100,
+		columns, err := selectTransferColumns(columnSpec)
+		if err != nil {
+			return err
 		}
 
-		transfers, err := queries.ListTransfersWithRefresh(apiToken, req, refresh)
+		since, until, err := transferRange(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to list transfers: %w", err)
+			return err
 		}
 
-		if len(transfers) == 0 {
-			fmt.Println("No transfers found")
-			return nil
+		req := queries.ListTransfersRequest{
+			ProfileID: profileID,
+			Status:    status,
+			Since:     &since,
+			Until:     &until,
 		}
 
 		// Fetch recipients to build ID -> Name map
 		recipientMap := make(map[int]string)
-		reqRecipients := queries.ListRecipientsRequest{Size: 1000}
-		if profileID != 0 {
-			reqRecipients.ProfileID = profileID
-		}
-		recipients, err := queries.ListRecipientsWithRefresh(apiToken, reqRecipients, refresh)
+		recipients, err := listRecipients(profileID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to fetch recipients: %v\n", err)
 		} else {
@@ -796,57 +931,76 @@ var transfersCmd = &cobra.Command{
 			}
 		}
 
-		// Format output
-		fmt.Printf("%-10s %-20s %-15s %-30s %-15s %-20s %-10s\n", "ID", "Date", "Source", "Recipient", "Target", "Reference", "Status")
-		fmt.Println(strings.Repeat("-", 135))
-
-		for _, t := range transfers {
-			reference := "-"
-			if t.Reference != nil && *t.Reference != "" {
-				reference = *t.Reference
+		matches := func(row transferRow) bool {
+			if expr != nil {
+				return expr.Match(row.record())
 			}
 
-			// Get recipient name
-			recipientName := recipientMap[t.TargetAccount]
-			if recipientName == "" {
-				recipientName = "-"
-			}
+			recipientName := strings.ToLower(row.recipientName)
+			reference := strings.ToLower(row.reference())
 
 			// Apply filters with substring matching
 			// If both filters are the same (from search-term), match if either recipient or reference matches
 			if recipientFilter != "" && referenceFilter != "" && recipientFilter == referenceFilter {
-				if !strings.Contains(strings.ToLower(recipientName), strings.ToLower(recipientFilter)) &&
-					!strings.Contains(strings.ToLower(reference), strings.ToLower(referenceFilter)) {
-					continue
-				}
-			} else {
-				// Otherwise apply filters independently
-				if recipientFilter != "" && !strings.Contains(strings.ToLower(recipientName), strings.ToLower(recipientFilter)) {
-					continue
-				}
-				if referenceFilter != "" && !strings.Contains(strings.ToLower(reference), strings.ToLower(referenceFilter)) {
-					continue
-				}
+				return strings.Contains(recipientName, strings.ToLower(recipientFilter)) ||
+					strings.Contains(reference, strings.ToLower(referenceFilter))
+			}
+			// Otherwise apply filters independently
+			if recipientFilter != "" && !strings.Contains(recipientName, strings.ToLower(recipientFilter)) {
+				return false
+			}
+			if referenceFilter != "" && !strings.Contains(reference, strings.ToLower(referenceFilter)) {
+				return false
 			}
+			return true
+		}
 
-			sourceStr := fmt.Sprintf("%.2f %s", t.SourceValue, t.SourceCurrency)
-			targetStr := fmt.Sprintf("%.2f %s", t.TargetValue, t.TargetCurrency)
+		// Without --sort rows are printed as pages arrive; the header is printed with the first match
+		var sorted []transferRow
+		printed := 0
+		err = eachTransfer(req, func(t queries.Transfer) error {
+			row := transferRow{transfer: t, recipientName: recipientMap[t.TargetAccount]}
+			if !matches(row) {
+				return nil
+			}
 
-			// Parse created date
-			createdDate := t.Created[:10] // YYYY-MM-DD format
-			if len(t.Created) > 10 {
-				createdDate = t.Created[:10]
+			if len(sortKeys) > 0 {
+				sorted = append(sorted, row)
+				return nil
 			}
 
-			fmt.Printf("%-10d %-20s %-15s %-30s %-15s %-20s %-10s\n",
-				t.ID,
-				createdDate,
-				sourceStr,
-				recipientName,
-				targetStr,
-				reference,
-				t.Status,
-			)
+			if printed == 0 {
+				printTransferHeader(columns)
+			}
+			printed++
+			printTransferRow(columns, row)
+			if limit > 0 && printed >= limit {
+				return errEnoughTransfers
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errEnoughTransfers) {
+			return fmt.Errorf("failed to list transfers: %w", err)
+		}
+
+		if len(sortKeys) > 0 {
+			sort.SliceStable(sorted, func(i, j int) bool {
+				return filter.Less(sortKeys, sorted[i].record(), sorted[j].record())
+			})
+			if limit > 0 && len(sorted) > limit {
+				sorted = sorted[:limit]
+			}
+			for _, row := range sorted {
+				if printed == 0 {
+					printTransferHeader(columns)
+				}
+				printed++
+				printTransferRow(columns, row)
+			}
+		}
+
+		if printed == 0 {
+			fmt.Println("No transfers found")
 		}
 
 		return nil
@@ -910,10 +1064,10 @@ description: Prepare and execute money transfers using the Wise API. Use when he
 }
 
 var sendToCmd = &cobra.Command{
-	Use:   "send-to <recipient-name> <amount> <currency> [reference]",
+	Use:   "send-to <recipient-name-or-alias> <amount> [currency] [reference]",
 	Short: "Send money to a recipient",
-	Long:  "Send money to a recipient by name, creating a quote and transfer automatically. Optional reference can be provided as 4th argument or --reference flag.",
-	Args:  cobra.RangeArgs(3, 4),
+	Long:  "Send money to a recipient by name or alias, creating a quote and transfer automatically. Optional reference can be provided as 4th argument or --reference flag. Currency, reference and source account default to the alias settings when an alias is used. The currency is the recipient's currency and is paid from the balance in the same currency unless --from-currency names another. By default the recipient receives exactly the amount; with --source-amount exactly the amount is debited instead.",
+	Args:  cobra.RangeArgs(2, 4),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if apiToken == "" {
 			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
@@ -925,28 +1079,58 @@ var sendToCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("invalid amount: %w", err)
 		}
-		currency := args[2]
 		profileID, _ := cmd.Flags().GetInt("profile-id")
 		sourceAccount, _ := cmd.Flags().GetInt("source-account")
 		reference, _ := cmd.Flags().GetString("reference")
 		customerTxID, _ := cmd.Flags().GetString("customer-transaction-id")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		invoice, _ := cmd.Flags().GetString("invoice")
+		whenRate, _ := cmd.Flags().GetString("when-rate")
+		expires, _ := cmd.Flags().GetString("expires")
+		payIn, _ := cmd.Flags().GetString("pay-in")
+		payIn = strings.ToUpper(payIn)
+		fromCurrency, _ := cmd.Flags().GetString("from-currency")
+		fixedSource, _ := cmd.Flags().GetBool("source-amount")
+		fixedTarget, _ := cmd.Flags().GetBool("target-amount")
+		detailPairs, _ := cmd.Flags().GetStringArray("detail")
+
+		details, err := parseKeyValues(detailPairs)
+		if err != nil {
+			return err
+		}
+
+		// Resolve the address book entry, if any, before looking up recipients
+		alias, err := config.LookupAlias(recipientName)
+		if err != nil {
+			return fmt.Errorf("failed to load aliases: %w", err)
+		}
+
+		currency := ""
+		if len(args) >= 3 {
+			currency = args[2]
+		} else if alias != nil {
+			currency = alias.Currency
+		}
 
 		// If reference is provided as 4th argument, use that (unless flag overrides it)
 		if len(args) == 4 && reference == "" {
 			reference = args[3]
 		}
 
-		// Use default profile if not specified
-		if profileID == 0 {
-			defaultProfile, err := config.LoadDefaultProfile()
-			if err != nil {
-				return fmt.Errorf("failed to load default profile: %w", err)
+		// Fall back to alias defaults for anything not given explicitly
+		if alias != nil {
+			if reference == "" {
+				reference = alias.Reference
 			}
-			if defaultProfile == 0 {
-				return fmt.Errorf("profile-id is required: use --profile-id or run 'wise-cli select-profile <id>'")
+			if sourceAccount == 0 {
+				sourceAccount = alias.SourceAccount
 			}
-			profileID = defaultProfile
+		}
+
+		// Use default profile if not specified
+		profileID, err = resolveProfileID(profileID)
+		if err != nil {
+			return err
 		}
 
 		// Auto-generate customer transaction ID if not provided
@@ -957,44 +1141,73 @@ var sendToCmd = &cobra.Command{
 		if amount == 0 {
 			return fmt.Errorf("amount is required and must be greater than 0")
 		}
-		if currency == "" {
+		if currency == "" && fromCurrency == "" {
 			return fmt.Errorf("currency is required")
 		}
+		if fixedSource && fixedTarget {
+			return fmt.Errorf("only one of --source-amount or --target-amount can be specified")
+		}
 
-		// Step 1: Find the recipient by name
-		fmt.Printf("Finding recipient: %s\n", recipientName)
-		recipients, err := queries.ListRecipientsWithRefresh(apiToken, queries.ListRecipientsRequest{
-			ProfileID: profileID,
-			Currency:  currency,
-		}, refresh)
+		// Step 1: Find the recipient by alias or name
+		targetRecipient, err := resolveRecipient(profileID, alias, recipientName, currency)
 		if err != nil {
-			return fmt.Errorf("failed to list recipients: %w", err)
+			return err
 		}
+		fmt.Printf("Found recipient: %s (ID: %d)\n", targetRecipient.Name.FullName, targetRecipient.ID)
 
-		var targetRecipient *queries.Recipient
+		// The currency argument names the recipient's currency; paying from
+		// another balance has to be asked for with --from-currency
+		if len(args) >= 3 && !strings.EqualFold(currency, targetRecipient.Currency) {
+			return fmt.Errorf("recipient %s receives %s, not %s: pay from another balance with --from-currency",
+				targetRecipient.Name.FullName, targetRecipient.Currency, strings.ToUpper(currency))
+		}
 
-		// Try exact match first
-		for i := range recipients {
-			if recipients[i].Name.FullName == recipientName {
-				targetRecipient = &recipients[i]
-				break
-			}
+		// Pay from the recipient currency unless another balance is chosen
+		sourceCurrency := strings.ToUpper(fromCurrency)
+		if sourceCurrency == "" {
+			sourceCurrency = targetRecipient.Currency
+		}
+		amountCurrency := targetRecipient.Currency
+		if fixedSource {
+			amountCurrency = sourceCurrency
 		}
 
-		// If not found, try substring match (case-insensitive)
-		if targetRecipient == nil {
-			for i := range recipients {
-				if strings.Contains(strings.ToLower(recipients[i].Name.FullName), strings.ToLower(recipientName)) {
-					targetRecipient = &recipients[i]
-					break
-				}
+		// A conditional order is stored and sent later by 'orders run', with
+		// the reference rendered at that time
+		if whenRate != "" {
+			seqKey := fmt.Sprintf("recipient-%d", targetRecipient.ID)
+			if _, err := renderReference(reference, targetRecipient, invoice, seqKey, time.Now(), false); err != nil {
+				return fmt.Errorf("invalid reference: %w", err)
 			}
+
+			order := config.Order{
+				Recipient:      recipientName,
+				RecipientID:    targetRecipient.ID,
+				Amount:         amount,
+				FixedSource:    fixedSource,
+				Currency:       sourceCurrency,
+				TargetCurrency: targetRecipient.Currency,
+				Reference:      reference,
+				Invoice:        invoice,
+				Details:        details,
+				SourceAccount:  sourceAccount,
+				ProfileID:      profileID,
+				Condition:      whenRate,
+				PayIn:          payIn,
+			}
+			if cmd.Flags().Changed("customer-transaction-id") {
+				order.CustomerTransactionID = customerTxID
+			}
+			return placeOrder(order, expires, dryRun)
 		}
 
-		if targetRecipient == nil {
-			return fmt.Errorf("recipient not found: %s", recipientName)
+		// Expand the reference template and check it against the payout currency rules
+		seqKey := fmt.Sprintf("recipient-%d", targetRecipient.ID)
+		now := time.Now()
+		rendered, err := renderReference(reference, targetRecipient, invoice, seqKey, now, false)
+		if err != nil {
+			return fmt.Errorf("invalid reference: %w", err)
 		}
-		fmt.Printf("Found recipient: %s (ID: %d)\n", targetRecipient.Name.FullName, targetRecipient.ID)
 
 		if dryRun {
 			// Dry-run mode: show what would happen without creating anything
@@ -1002,83 +1215,55 @@ var sendToCmd = &cobra.Command{
 			fmt.Println("============================================")
 			fmt.Printf("Recipient:               %s (ID: %d)\n", targetRecipient.Name.FullName, targetRecipient.ID)
 			fmt.Printf("Recipient Currency:      %s\n", targetRecipient.Currency)
-			fmt.Printf("Target Amount:           %.2f %s\n", amount, targetRecipient.Currency)
+			fmt.Printf("Source Currency:         %s\n", sourceCurrency)
+			if fixedSource {
+				fmt.Printf("Source Amount (fixed):   %.2f %s debited, the recipient gets what it converts to\n", amount, amountCurrency)
+			} else {
+				fmt.Printf("Target Amount (fixed):   %.2f %s received, the debit includes conversion and fees\n", amount, amountCurrency)
+			}
 			fmt.Printf("Profile ID:              %d\n", profileID)
 			fmt.Printf("Customer Transaction ID: %s\n", customerTxID)
+			fmt.Printf("Pay-in Method:           %s\n", payIn)
 
-			if reference != "" {
-				fmt.Printf("Reference:               %s\n", reference)
+			if rendered != "" {
+				fmt.Printf("Reference:               %s\n", rendered)
 			}
 			if sourceAccount != 0 {
 				fmt.Printf("Source Account:          %d\n", sourceAccount)
 			}
+			detailKeys := make([]string, 0, len(details))
+			for key := range details {
+				detailKeys = append(detailKeys, key)
+			}
+			sort.Strings(detailKeys)
+			for _, key := range detailKeys {
+				fmt.Printf("Detail:                  %s=%s\n", key, details[key])
+			}
 
 			fmt.Println("\nWhat would happen:")
-			fmt.Printf("- Create a quote for %.2f %s → %s\n", amount, currency, targetRecipient.Currency)
+			fmt.Printf("- Create a quote %s\n", describeQuote(amount, sourceCurrency, targetRecipient.Currency, fixedSource))
+			fmt.Println("- Check the transfer requirements and ask for any missing details")
 			fmt.Println("- Create a transfer with the quote")
 			fmt.Println("\nRun without --dry-run to actually create the transfer")
 			return nil
 		}
 
-		// Step 2: Create a quote
-		fmt.Printf("Creating quote: %.2f %s → %s\n", amount, currency, targetRecipient.Currency)
-		quoteReq := commands.NewQuoteRequest{
-			ProfileID:      profileID,
-			SourceCurrency: currency,
-			TargetCurrency: targetRecipient.Currency,
-			TargetAmount:   &amount,
-		}
-
-		quote, err := commands.NewQuote(apiToken, quoteReq)
-		if err != nil {
-			return fmt.Errorf("failed to create quote: %w", err)
-		}
-		fmt.Printf("Quote created: %s\n", quote.ID)
-
-		// Step 3: Create a transfer
-		fmt.Println("Creating transfer...")
-		if customerTxID == "" {
-			return fmt.Errorf("customer-transaction-id is required for transfer")
-		}
-
-		transferReq := commands.NewTransferRequest{
-			TargetAccount:         targetRecipient.ID,
-			QuoteUUID:             quote.ID,
-			CustomerTransactionID: customerTxID,
-		}
-
-		if reference != "" {
-			transferReq.Reference = &reference
-		}
-		if sourceAccount != 0 {
-			transferReq.SourceAccount = &sourceAccount
-		}
-
-		transfer, err := commands.NewTransfer(apiToken, transferReq)
+		// Steps 2 and 3: Create a quote and a transfer
+		transfer, err := executeSend(sendRequest{
+			ProfileID:        profileID,
+			Recipient:        targetRecipient,
+			Amount:           amount,
+			FixedSource:      fixedSource,
+			Currency:         sourceCurrency,
+			Reference:        rendered,
+			SourceAccount:    sourceAccount,
+			CustomerTxID:     customerTxID,
+			PayIn:            payIn,
+			Details:          details,
+			ReserveReference: reserveReference(reference, targetRecipient, invoice, seqKey, now),
+		})
 		if err != nil {
-			return fmt.Errorf("failed to create transfer: %w", err)
-		}
-
-		// Save transfer to cache
-		transferData := config.TransferData{
-			ID:                    transfer.ID,
-			Status:                transfer.Status,
-			SourceValue:           transfer.SourceValue,
-			SourceCurrency:        transfer.SourceCurrency,
-			TargetValue:           transfer.TargetValue,
-			TargetCurrency:        transfer.TargetCurrency,
-			Rate:                  transfer.Rate,
-			Created:               transfer.Created,
-			QuoteUUID:             transfer.QuoteUUID,
-			CustomerTransactionID: transfer.CustomerTransactionID,
-			TargetAccount:         transfer.TargetAccount,
-			Reference:             transfer.Reference,
-			SourceAccount:         transfer.SourceAccount,
-			PayinSessionID:        transfer.PayinSessionID,
-			HasActiveIssues:       transfer.HasActiveIssues,
-		}
-		if err := config.SaveTransfer(customerTxID, transferData); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save transfer to cache: %v\n", err)
+			return err
 		}
 
 		// Format output
@@ -1087,8 +1272,12 @@ var sendToCmd = &cobra.Command{
 		fmt.Printf("Transfer ID:             %d\n", transfer.ID)
 		fmt.Printf("Status:                  %s\n", transfer.Status)
 		fmt.Printf("Recipient:               %s\n", recipientName)
-		fmt.Printf("Source:                  %.2f %s\n", transfer.SourceValue, transfer.SourceCurrency)
-		fmt.Printf("Target:                  %.2f %s\n", transfer.TargetValue, transfer.TargetCurrency)
+		sourceLabel, targetLabel := "Source:", "Target (fixed):"
+		if fixedSource {
+			sourceLabel, targetLabel = "Source (fixed):", "Target:"
+		}
+		fmt.Printf("%-24s %.2f %s\n", sourceLabel, transfer.SourceValue, transfer.SourceCurrency)
+		fmt.Printf("%-24s %.2f %s\n", targetLabel, transfer.TargetValue, transfer.TargetCurrency)
 		fmt.Printf("Exchange Rate:           %.6f\n", transfer.Rate)
 		fmt.Printf("Quote ID:                %s\n", transfer.QuoteUUID)
 		fmt.Printf("Customer Transaction ID: %s\n", transfer.CustomerTransactionID)
@@ -1098,10 +1287,391 @@ var sendToCmd = &cobra.Command{
 			fmt.Printf("Reference:               %s\n", *transfer.Reference)
 		}
 
+		if payIn != "BALANCE" {
+			fmt.Printf("\nThe transfer waits for your %s payment; fund it in Wise to complete it.\n", payIn)
+		}
+
 		return nil
 	},
 }
 
+// transferRange reads the --days, --since and --until flags of a transfer
+// listing command. --since takes precedence over --days, which counts back
+// from --until.
+func transferRange(cmd *cobra.Command) (time.Time, time.Time, error) {
+	days, _ := cmd.Flags().GetInt("days")
+	sinceStr, _ := cmd.Flags().GetString("since")
+	untilStr, _ := cmd.Flags().GetString("until")
+
+	until := time.Now()
+	if untilStr != "" {
+		parsed, dateOnly, err := parseDateTime(untilStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --until: %w", err)
+		}
+		until = parsed
+		if dateOnly {
+			// A date includes the whole day
+			until = until.AddDate(0, 0, 1).Add(-time.Second)
+		}
+	}
+
+	since := until.AddDate(0, 0, -days)
+	if sinceStr != "" {
+		parsed, _, err := parseDateTime(sinceStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --since: %w", err)
+		}
+		since = parsed
+	}
+
+	if until.Before(since) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--until %s is before --since %s", until.Format(time.RFC3339), since.Format(time.RFC3339))
+	}
+
+	return since, until, nil
+}
+
+// parseDateTime parses a YYYY-MM-DD date or an RFC 3339 timestamp and
+// reports whether only a date was given
+func parseDateTime(s string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%q is not a YYYY-MM-DD date or RFC 3339 timestamp", s)
+	}
+	return t, false, nil
+}
+
+// resolveProfileID returns the given profile ID, falling back to the default profile
+func resolveProfileID(profileID int) (int, error) {
+	if profileID != 0 {
+		return profileID, nil
+	}
+
+	defaultProfile, err := config.LoadDefaultProfile()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load default profile: %w", err)
+	}
+	if defaultProfile == 0 {
+		return 0, fmt.Errorf("profile-id is required: use --profile-id or run 'wise-cli select-profile <id>'")
+	}
+
+	return defaultProfile, nil
+}
+
+// resolveRecipient finds the recipient an alias points to, or otherwise
+// the recipient whose name matches exactly or as a case-insensitive substring
+func resolveRecipient(profileID int, alias *config.Alias, recipientName, currency string) (*queries.Recipient, error) {
+	var targetRecipient *queries.Recipient
+
+	if alias != nil {
+		fmt.Printf("Resolving alias: %s → recipient %d\n", alias.Name, alias.RecipientID)
+		recipient, err := findRecipientByID(profileID, alias.RecipientID)
+		if err != nil {
+			return nil, err
+		}
+		targetRecipient = recipient
+	} else {
+		fmt.Printf("Finding recipient: %s\n", recipientName)
+		recipients, err := queries.ListRecipientsWithRefresh(apiToken, queries.ListRecipientsRequest{
+			ProfileID: profileID,
+			Currency:  currency,
+		}, refresh)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list recipients: %w", err)
+		}
+
+		targetRecipient = matchRecipientName(recipients, recipientName)
+	}
+
+	if targetRecipient == nil {
+		return nil, fmt.Errorf("recipient not found: %s", recipientName)
+	}
+
+	return targetRecipient, nil
+}
+
+// matchRecipientName returns the recipient whose name matches exactly, or
+// otherwise as a case-insensitive substring, or nil if none does
+func matchRecipientName(recipients []queries.Recipient, name string) *queries.Recipient {
+	// Try exact match first
+	for i := range recipients {
+		if recipients[i].Name.FullName == name {
+			return &recipients[i]
+		}
+	}
+
+	// If not found, try substring match (case-insensitive)
+	for i := range recipients {
+		if strings.Contains(strings.ToLower(recipients[i].Name.FullName), strings.ToLower(name)) {
+			return &recipients[i]
+		}
+	}
+
+	return nil
+}
+
+// findQuoteRecipient resolves a recipient given as an ID, an alias or a name
+func findQuoteRecipient(profileID int, recipientArg, currency string) (*queries.Recipient, error) {
+	if id, err := strconv.Atoi(recipientArg); err == nil {
+		recipient, err := findRecipientByID(profileID, id)
+		if err != nil {
+			return nil, err
+		}
+		if recipient == nil {
+			return nil, fmt.Errorf("recipient not found: %d", id)
+		}
+		return recipient, nil
+	}
+
+	alias, err := config.LookupAlias(recipientArg)
+	if err != nil {
+		return nil, err
+	}
+	return resolveRecipient(profileID, alias, recipientArg, strings.ToUpper(currency))
+}
+
+// renderReference expands a reference template for a recipient and checks the
+// result against the reference rules of the recipient currency. A preview
+// shows the next {{seq}} number; with reserve set the number is taken for a
+// payment that is about to be created.
+func renderReference(tmpl string, recipient *queries.Recipient, invoice, seqKey string, at time.Time, reserve bool) (string, error) {
+	if tmpl == "" {
+		return "", nil
+	}
+
+	seq := 0
+	if paymentref.UsesSeq(tmpl) {
+		if reserve {
+			reserved, err := config.ReserveSequence(seqKey)
+			if err != nil {
+				return "", fmt.Errorf("failed to reserve reference sequence: %w", err)
+			}
+			seq = reserved
+		} else {
+			last, err := config.LoadSequence(seqKey)
+			if err != nil {
+				return "", err
+			}
+			seq = last + 1
+		}
+	}
+
+	rendered, err := paymentref.Render(tmpl, paymentref.Context{
+		Time:          at,
+		RecipientName: recipient.Name.FullName,
+		Invoice:       invoice,
+		Seq:           seq,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Warnings are printed by the preview, which every send renders first
+	if reserve {
+		_, err = paymentref.Validate(rendered, recipient.Currency)
+		return rendered, err
+	}
+	if err := checkReference(rendered, recipient.Currency); err != nil {
+		return "", err
+	}
+
+	return rendered, nil
+}
+
+// reserveReference returns a function that renders a reference template with
+// a newly reserved {{seq}} number, or nil if the template has no {{seq}}
+func reserveReference(tmpl string, recipient *queries.Recipient, invoice, seqKey string, at time.Time) func() (string, error) {
+	if !paymentref.UsesSeq(tmpl) {
+		return nil
+	}
+	return func() (string, error) {
+		return renderReference(tmpl, recipient, invoice, seqKey, at, true)
+	}
+}
+
+// transferTargetCurrency returns the payout currency of a transfer, from the
+// stored quote if the CLI created it and from the recipient otherwise
+func transferTargetCurrency(quoteID string, targetAccount int) (string, error) {
+	stored, err := config.LookupQuote(quoteID)
+	if err != nil {
+		return "", err
+	}
+	if stored != nil && stored.TargetCurrency != "" {
+		return stored.TargetCurrency, nil
+	}
+
+	recipient, err := queries.GetRecipient(apiToken, targetAccount)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch recipient %d: %w", targetAccount, err)
+	}
+	return recipient.Currency, nil
+}
+
+// checkReference validates a reference for the payout currency, printing a
+// warning for currencies without documented reference limits
+func checkReference(reference, currency string) error {
+	warning, err := paymentref.Validate(reference, currency)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	return nil
+}
+
+// sendRequest holds the resolved parameters for sending money to a recipient
+type sendRequest struct {
+	ProfileID     int
+	Recipient     *queries.Recipient
+	Amount        float64
+	FixedSource   bool   // Amount is debited in Currency rather than received in the recipient's currency
+	Currency      string // currency paid from
+	Reference     string
+	SourceAccount int
+	CustomerTxID  string
+	PayIn         string                            // defaults to BALANCE
+	Details       map[string]string                 // transfer details by requirement key, e.g. transferPurpose
+	CheckQuote    func(quote *commands.Quote) error // optional, refuses a quote before the transfer is created
+	// ReserveReference optionally renders the final reference once the quote
+	// was accepted, reserving its {{seq}} number (see reserveReference)
+	ReserveReference func() (string, error)
+}
+
+// transferDetailUsage describes the --detail flag of commands that create transfers
+const transferDetailUsage = "Transfer detail required by some corridors as key=value, e.g. --detail transferPurpose=verification.transfers.purpose.pay.bills (repeatable)"
+
+// describeQuote says which side of a conversion is fixed, such as
+// "debiting exactly 1000.00 EUR, converted to GBP"
+func describeQuote(amount float64, sourceCurrency, targetCurrency string, fixedSource bool) string {
+	if fixedSource {
+		return fmt.Sprintf("debiting exactly %.2f %s, converted to %s", amount, sourceCurrency, targetCurrency)
+	}
+	return fmt.Sprintf("delivering exactly %.2f %s, paid from %s", amount, targetCurrency, sourceCurrency)
+}
+
+// executeSend creates a quote and a transfer for a resolved recipient and
+// records the transfer in the local transfer store
+func executeSend(req sendRequest) (*commands.Transfer, error) {
+	if req.CustomerTxID == "" {
+		return nil, fmt.Errorf("customer-transaction-id is required for transfer")
+	}
+
+	// Create a quote
+	fmt.Printf("Creating quote %s\n", describeQuote(req.Amount, req.Currency, req.Recipient.Currency, req.FixedSource))
+	amount := req.Amount
+	quoteReq := commands.NewQuoteRequest{
+		ProfileID:      req.ProfileID,
+		SourceCurrency: req.Currency,
+		TargetCurrency: req.Recipient.Currency,
+		PayIn:          req.PayIn,
+	}
+	if req.FixedSource {
+		quoteReq.SourceAmount = &amount
+	} else {
+		quoteReq.TargetAmount = &amount
+	}
+
+	quote, err := commands.NewQuote(apiToken, quoteReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quote: %w", err)
+	}
+	fmt.Printf("Quote created: %s\n", quote.ID)
+	rememberQuote(quote, req.ProfileID, req.PayIn, req.Recipient.ID)
+
+	// Price the quote for the recipient before it is used
+	quote, err = attachRecipient(quote, req.ProfileID, req.Recipient.ID, req.PayIn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse pay-in methods the quote says cannot be used
+	if req.PayIn != "" {
+		option := quote.PaymentOption(req.PayIn)
+		if option == nil {
+			return nil, fmt.Errorf("pay-in method %s is not offered for this quote (see 'wise quote compare')", req.PayIn)
+		}
+		if option.Disabled {
+			reason := "no reason given"
+			if option.DisabledReason != nil {
+				reason = option.DisabledReason.Message
+			}
+			return nil, fmt.Errorf("pay-in method %s is not available: %s", req.PayIn, reason)
+		}
+	}
+
+	if req.CheckQuote != nil {
+		if err := req.CheckQuote(quote); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.ReserveReference != nil {
+		reference, err := req.ReserveReference()
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference: %w", err)
+		}
+		req.Reference = reference
+	}
+
+	details, err := collectTransferDetails(quote.ID, req.Recipient.ID, req.Reference, req.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a transfer
+	fmt.Println("Creating transfer...")
+	transferReq := commands.NewTransferRequest{
+		TargetAccount:         req.Recipient.ID,
+		QuoteUUID:             quote.ID,
+		CustomerTransactionID: req.CustomerTxID,
+		Details:               details,
+	}
+
+	if req.Reference != "" {
+		reference := req.Reference
+		transferReq.Reference = &reference
+	}
+	if req.SourceAccount != 0 {
+		sourceAccount := req.SourceAccount
+		transferReq.SourceAccount = &sourceAccount
+	}
+
+	transfer, err := commands.NewTransfer(apiToken, transferReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transfer: %w", err)
+	}
+	markQuoteUsed(quote.ID, transfer.ID, 0)
+
+	// Save transfer to cache
+	transferData := config.TransferData{
+		ID:                    transfer.ID,
+		Status:                transfer.Status,
+		SourceValue:           transfer.SourceValue,
+		SourceCurrency:        transfer.SourceCurrency,
+		TargetValue:           transfer.TargetValue,
+		TargetCurrency:        transfer.TargetCurrency,
+		Rate:                  transfer.Rate,
+		Created:               transfer.Created,
+		QuoteUUID:             transfer.QuoteUUID,
+		CustomerTransactionID: transfer.CustomerTransactionID,
+		TargetAccount:         transfer.TargetAccount,
+		Reference:             transfer.Reference,
+		SourceAccount:         transfer.SourceAccount,
+		PayinSessionID:        transfer.PayinSessionID,
+		HasActiveIssues:       transfer.HasActiveIssues,
+	}
+	if err := config.SaveTransfer(req.CustomerTxID, transferData); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save transfer to cache: %v\n", err)
+	}
+
+	return transfer, nil
+}
+
 func init() {
 	recipientsCmd.Flags().IntP("profile-id", "p", 0, "Profile ID to filter by")
 	recipientsCmd.Flags().StringP("currency", "c", "", "Filter by currency (e.g. USD,GBP)")
@@ -1125,6 +1695,8 @@ func init() {
 	newQuoteCmd.MarkFlagRequired("target-currency")
 	newQuoteCmd.Flags().Float64("source-amount", 0, "Amount in source currency (either this or target-amount)")
 	newQuoteCmd.Flags().Float64("target-amount", 0, "Amount in target currency (either this or source-amount)")
+	newQuoteCmd.Flags().String("recipient", "", "Recipient ID, alias or name to price the quote for (optional)")
+	newQuoteCmd.Flags().String("pay-in", "BALANCE", "Preferred pay-in method: BALANCE, BANK_TRANSFER or another method listed by 'quote compare'")
 
 	quoteCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (required)")
 	quoteCmd.MarkFlagRequired("profile-id")
@@ -1143,23 +1715,34 @@ func init() {
 	newTransferCmd.MarkFlagRequired("customer-transaction-id")
 	newTransferCmd.Flags().StringP("reference", "r", "", "Payment reference (optional)")
 	newTransferCmd.Flags().IntP("source-account", "s", 0, "Source account ID (optional)")
+	newTransferCmd.Flags().Bool("no-refresh", false, "Fail instead of refreshing an expired quote")
+	newTransferCmd.Flags().StringArray("detail", nil, transferDetailUsage)
 
 	sendToCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
 	sendToCmd.Flags().StringP("customer-transaction-id", "c", "", "Customer transaction ID (optional, auto-generated if not set)")
-	sendToCmd.Flags().StringP("reference", "r", "", "Payment reference (optional)")
+	sendToCmd.Flags().StringP("reference", "r", "", "Payment reference, may contain {{date:LAYOUT}}, {{month}}, {{seq}}, {{recipient.name}} and {{invoice}} (optional)")
+	sendToCmd.Flags().String("invoice", "", "Invoice number for the {{invoice}} reference placeholder (optional)")
 	sendToCmd.Flags().IntP("source-account", "s", 0, "Source account ID (optional)")
 	sendToCmd.Flags().BoolP("dry-run", "n", false, "Validate without creating quote or transfer")
+	sendToCmd.Flags().String("when-rate", "", "Place a conditional order sent by 'orders run' once the rate meets this condition, e.g. \">=1.10\" (optional)")
+	sendToCmd.Flags().StringArray("detail", nil, transferDetailUsage)
+	sendToCmd.Flags().String("from-currency", "", "Balance currency to pay from (default: the currency argument)")
+	sendToCmd.Flags().Bool("source-amount", false, "The amount is debited from the balance exactly; the recipient gets what it converts to")
+	sendToCmd.Flags().Bool("target-amount", false, "The amount is what the recipient receives (default)")
+	sendToCmd.Flags().String("pay-in", "BALANCE", "Pay-in method: BALANCE, BANK_TRANSFER or another method listed by 'quote compare'")
+	sendToCmd.Flags().String("expires", "7d", "How long a conditional order stays pending, e.g. 7d or 12h")
 
 	// newRecipientCmd flags
 	newRecipientCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (required)")
 	newRecipientCmd.MarkFlagRequired("profile-id")
 	newRecipientCmd.Flags().StringP("currency", "c", "", "Recipient currency code (required)")
 	newRecipientCmd.MarkFlagRequired("currency")
-	newRecipientCmd.Flags().StringP("type", "t", "", "Recipient type: sort_code, iban, us, email (required)")
+	newRecipientCmd.Flags().StringP("type", "t", "", "Recipient type: sort_code, iban, us, email or any type from the account requirements, e.g. indian (required)")
 	newRecipientCmd.MarkFlagRequired("type")
 	newRecipientCmd.Flags().StringP("account-holder-name", "n", "", "Account holder full name (required)")
 	newRecipientCmd.MarkFlagRequired("account-holder-name")
 	newRecipientCmd.Flags().BoolP("owned-by-customer", "o", true, "Whether account is owned by customer (default: true)")
+	newRecipientCmd.Flags().Bool("allow-duplicate", false, "Create the recipient even if one for the same account already exists")
 
 	// Type-specific flags
 	newRecipientCmd.Flags().StringP("sort-code", "", "", "Sort code (required for sort_code type)")
@@ -1170,10 +1753,20 @@ func init() {
 	newRecipientCmd.Flags().StringP("email", "", "", "Email address (required for email type)")
 	newRecipientCmd.Flags().StringP("legal-type", "", "", "Legal type: PRIVATE or BUSINESS (optional)")
 
+	// Requirement-driven flags for all other recipient types
+	newRecipientCmd.Flags().StringArray("field", nil, "Account detail as key=value, e.g. --field ifscCode=YESB0236041 (repeatable; uses account requirements)")
+	newRecipientCmd.Flags().String("source-currency", "", "Source currency used to look up account requirements (default: recipient currency)")
+	newRecipientCmd.Flags().Float64("source-amount", 1000, "Source amount used to look up account requirements")
+
 	// Transfers command flags
 	transfersCmd.Flags().IntP("profile-id", "p", 0, "Profile ID to filter by (optional)")
 	transfersCmd.Flags().StringP("status", "s", "", "Filter by transfer status (e.g. incoming, outgoing, cancelled)")
 	transfersCmd.Flags().IntP("days", "d", 30, "Number of days to look back (default 30)")
+	transfersCmd.Flags().String("since", "", "Only transfers created on or after this date (YYYY-MM-DD or RFC 3339), overrides --days")
+	transfersCmd.Flags().String("until", "", "Only transfers created on or before this date (YYYY-MM-DD or RFC 3339, default: now)")
+	transfersCmd.Flags().IntP("limit", "l", 0, "Maximum number of matching transfers to show (default: all)")
+	transfersCmd.Flags().String("sort", "", "Sort by comma-separated fields, prefix with - for descending (e.g. -amount,date)")
+	transfersCmd.Flags().String("columns", defaultTransferColumns, "Comma-separated columns to show")
 	transfersCmd.Flags().StringP("recipient", "r", "", "Filter by recipient name (substring match, case-insensitive)")
 	transfersCmd.Flags().String("reference", "", "Filter by reference (substring match, case-insensitive)")
 }
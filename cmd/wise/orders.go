@@ -0,0 +1,477 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dhamidi/wise-cli/commands"
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var ordersCmd = &cobra.Command{
+	Use:   "orders",
+	Short: "Manage conditional orders",
+	Long:  "Manage payments placed with 'send-to --when-rate' that are sent by 'orders run' once the exchange rate meets their condition",
+}
+
+var ordersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List conditional orders",
+	Long:  "List pending conditional orders with their condition, last seen rate and expiry. Use --all to include executed, cancelled and expired orders.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+
+		orders, err := config.LoadOrders()
+		if err != nil {
+			return err
+		}
+
+		var shown []config.Order
+		for _, o := range orders {
+			if all || o.Status == config.OrderPending {
+				shown = append(shown, o)
+			}
+		}
+
+		if len(shown) == 0 {
+			fmt.Println("No orders found")
+			return nil
+		}
+
+		fmt.Printf("%-10s %-20s %-15s %-9s %-10s %-10s %-18s %-10s\n", "ID", "Recipient", "Amount", "Pair", "Condition", "Last Rate", "Expires", "Status")
+		fmt.Println(strings.Repeat("-", 109))
+
+		for _, o := range shown {
+			lastRate := "-"
+			if !o.CheckedAt.IsZero() {
+				lastRate = strconv.FormatFloat(o.LastRate, 'f', 4, 64)
+			}
+
+			status := o.Status
+			if o.Status == config.OrderExecuted {
+				status = fmt.Sprintf("%s (transfer %d)", o.Status, o.TransferID)
+			}
+
+			fmt.Printf("%-10s %-20s %-15s %-9s %-10s %-10s %-18s %-10s\n",
+				o.ID,
+				o.Recipient,
+				fmt.Sprintf("%.2f %s", o.Amount, o.AmountCurrency()),
+				o.Currency+"/"+o.TargetCurrency,
+				o.Condition,
+				lastRate,
+				o.ExpiresAt.Format("2006-01-02 15:04"),
+				status,
+			)
+		}
+
+		return nil
+	},
+}
+
+var ordersCancelCmd = &cobra.Command{
+	Use:   "cancel <order-id>",
+	Short: "Cancel a conditional order",
+	Long:  "Cancel a pending conditional order so that 'orders run' never sends it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		release, err := config.AcquireLock("orders")
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		orders, err := config.LoadOrders()
+		if err != nil {
+			return err
+		}
+
+		var order *config.Order
+		for i := range orders {
+			if orders[i].ID == args[0] {
+				order = &orders[i]
+			}
+		}
+		if order == nil {
+			return fmt.Errorf("order not found: %s", args[0])
+		}
+		if order.Status != config.OrderPending {
+			return fmt.Errorf("order %s is %s and can no longer be cancelled", order.ID, order.Status)
+		}
+
+		order.Status = config.OrderCancelled
+		if err := config.SaveOrders(orders); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Cancelled order %s\n", order.ID)
+		return nil
+	},
+}
+
+var ordersRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Send conditional orders whose rate condition holds",
+	Long: `Check the mid-market rate of every pending order and send the orders whose condition holds, with a quote
+and transfer created under the order's deterministic customer transaction ID. Orders past their expiry are marked
+expired. Without --interval, check once and exit, e.g. from cron; with --interval, keep checking until no orders
+are pending or the process is stopped.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		interval, _ := cmd.Flags().GetDuration("interval")
+
+		if interval == 0 {
+			_, err := runOrders(dryRun)
+			return err
+		}
+		if interval < minWatchInterval {
+			return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			pending, err := runOrders(dryRun)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			if pending == 0 && err == nil {
+				fmt.Println("No pending orders left")
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// placeOrder stores a conditional order for a resolved recipient instead of sending right away
+func placeOrder(order config.Order, expires string, dryRun bool) error {
+	condition, err := config.ParseRateCondition(order.Condition)
+	if err != nil {
+		return err
+	}
+	order.Condition = condition.String()
+
+	if strings.EqualFold(order.Currency, order.TargetCurrency) {
+		return fmt.Errorf("--when-rate needs a currency conversion, but %s is sent to a %s recipient", order.Currency, order.TargetCurrency)
+	}
+
+	validity, err := parseAge(expires)
+	if err != nil {
+		return fmt.Errorf("invalid --expires: %w", err)
+	}
+
+	order.ID = uuid.New().String()[:8]
+	order.Status = config.OrderPending
+	order.CreatedAt = time.Now()
+	order.ExpiresAt = order.CreatedAt.Add(validity)
+	if order.CustomerTransactionID == "" {
+		order.CustomerTransactionID = orderTransactionID(order.ID)
+	}
+
+	if dryRun {
+		fmt.Println("\n📋 Dry-run mode - no order will be saved")
+		fmt.Println("========================================")
+		fmt.Printf("Recipient:               %s (ID: %d)\n", order.Recipient, order.RecipientID)
+		fmt.Printf("Amount (fixed):          %.2f %s\n", order.Amount, order.AmountCurrency())
+		fmt.Printf("Condition:               %s/%s %s\n", order.Currency, order.TargetCurrency, order.Condition)
+		fmt.Printf("Expires:                 %s\n", order.ExpiresAt.Format("2006-01-02 15:04"))
+		fmt.Println("\nRun without --dry-run to place the order")
+		return nil
+	}
+
+	release, err := config.AcquireLock("orders")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	orders, err := config.LoadOrders()
+	if err != nil {
+		return err
+	}
+	orders = append(orders, order)
+	if err := config.SaveOrders(orders); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Order %s placed: %.2f %s to %s when %s/%s %s\n",
+		order.ID, order.Amount, order.AmountCurrency(), order.Recipient, order.Currency, order.TargetCurrency, order.Condition)
+	fmt.Printf("Expires: %s. Run 'wise orders run' to check and send it.\n", order.ExpiresAt.Format("2006-01-02 15:04"))
+	return nil
+}
+
+// orderFailed is reported by sendPendingOrder when sending failed and the order stays pending
+const orderFailed = "failed"
+
+// errQuoteRateMissed is returned when the rate condition held for the
+// mid-market rate but not for the rate of the quote created for the transfer
+var errQuoteRateMissed = errors.New("quote rate does not meet the condition")
+
+// runOrders checks every pending order once and returns how many are still
+// pending. The "orders" lock is only held while orders.json is read or written
+// and while an order is being sent, so 'orders cancel' works during a run.
+func runOrders(dryRun bool) (int, error) {
+	releaseRun, err := config.AcquireLock("orders-run")
+	if err != nil {
+		return 0, err
+	}
+	defer releaseRun()
+
+	orders, err := config.LoadOrders()
+	if err != nil {
+		return 0, err
+	}
+
+	rates := make(map[string]float64)
+	pending, executed, failed := 0, 0, 0
+	for _, o := range orders {
+		if o.Status != config.OrderPending {
+			continue
+		}
+
+		now := time.Now()
+		if now.After(o.ExpiresAt) {
+			fmt.Printf("Order %s: expired without the rate reaching %s\n", o.ID, o.Condition)
+			if !dryRun {
+				if err := updateOrder(o.ID, func(current *config.Order) {
+					current.Status = config.OrderExpired
+				}); err != nil {
+					return pending, err
+				}
+			}
+			continue
+		}
+
+		condition, err := config.ParseRateCondition(o.Condition)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Order %s: %v\n", o.ID, err)
+			failed++
+			pending++
+			continue
+		}
+
+		// Each pair is fetched once per run, however many orders it has
+		pair := o.Currency + "/" + o.TargetCurrency
+		rate, ok := rates[pair]
+		if !ok {
+			current, err := queries.GetRates(apiToken, queries.RatesRequest{Source: o.Currency, Target: o.TargetCurrency}, true)
+			if err != nil || len(current) == 0 {
+				fmt.Fprintf(os.Stderr, "Order %s: failed to get %s rate: %v\n", o.ID, pair, err)
+				failed++
+				pending++
+				continue
+			}
+			rate = current[0].Rate
+			rates[pair] = rate
+		}
+
+		if !condition.Holds(rate) {
+			fmt.Printf("Order %s: %s at %.6f, waiting for %s\n", o.ID, pair, rate, o.Condition)
+			if !dryRun {
+				if err := updateOrder(o.ID, func(current *config.Order) {
+					current.LastRate, current.CheckedAt = rate, now
+				}); err != nil {
+					return pending, err
+				}
+			}
+			pending++
+			continue
+		}
+
+		if dryRun {
+			fmt.Printf("Order %s: %s at %.6f meets %s, would send %.2f %s to %s (customer transaction ID %s)\n",
+				o.ID, pair, rate, o.Condition, o.Amount, o.AmountCurrency(), o.Recipient, o.CustomerTransactionID)
+			pending++
+			continue
+		}
+
+		outcome, err := sendPendingOrder(o.ID, condition, rate, now)
+		if err != nil {
+			return pending, err
+		}
+		switch outcome {
+		case config.OrderExecuted:
+			executed++
+		case config.OrderPending:
+			pending++
+		case orderFailed:
+			failed++
+			pending++
+		}
+	}
+
+	if !dryRun {
+		fmt.Printf("%d executed, %d pending, %d failed\n", executed, pending, failed)
+	}
+	if failed > 0 {
+		return pending, fmt.Errorf("%d order(s) failed", failed)
+	}
+
+	return pending, nil
+}
+
+// sendPendingOrder sends an order under the orders lock, after checking that
+// it was not cancelled since the run started. It returns the order's status
+// afterwards, or orderFailed if sending failed and the order stays pending.
+func sendPendingOrder(orderID string, condition config.RateCondition, rate float64, checkedAt time.Time) (string, error) {
+	release, err := config.AcquireLock("orders")
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	orders, err := config.LoadOrders()
+	if err != nil {
+		return "", err
+	}
+	var o *config.Order
+	for i := range orders {
+		if orders[i].ID == orderID {
+			o = &orders[i]
+		}
+	}
+	if o == nil || o.Status != config.OrderPending {
+		fmt.Printf("Order %s: no longer pending, skipping\n", orderID)
+		return "", nil
+	}
+
+	pair := o.Currency + "/" + o.TargetCurrency
+	o.LastRate, o.CheckedAt = rate, checkedAt
+	fmt.Printf("Order %s: %s at %.6f meets %s, sending %.2f %s to %s\n",
+		o.ID, pair, rate, o.Condition, o.Amount, o.AmountCurrency(), o.Recipient)
+
+	outcome := config.OrderExecuted
+	transferID, err := executeOrder(o, condition)
+	switch {
+	case errors.Is(err, errQuoteRateMissed):
+		fmt.Printf("Order %s: %v, waiting for %s\n", o.ID, err, o.Condition)
+		outcome = config.OrderPending
+	case err != nil:
+		// The order stays pending and is retried with the same customer transaction ID
+		o.Error = err.Error()
+		fmt.Fprintf(os.Stderr, "Order %s: %v\n", o.ID, err)
+		outcome = orderFailed
+	default:
+		o.Status = config.OrderExecuted
+		o.ExecutedAt = time.Now()
+		o.TransferID = transferID
+		o.Error = ""
+		fmt.Printf("Order %s: ✓ transfer %d\n", o.ID, transferID)
+	}
+
+	// Persist progress after every order so a crash never repeats finished work
+	if err := config.SaveOrders(orders); err != nil {
+		return "", err
+	}
+	return outcome, nil
+}
+
+// updateOrder changes one order under the orders lock, leaving it alone if it
+// is no longer pending
+func updateOrder(orderID string, update func(o *config.Order)) error {
+	release, err := config.AcquireLock("orders")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	orders, err := config.LoadOrders()
+	if err != nil {
+		return err
+	}
+	for i := range orders {
+		if orders[i].ID == orderID && orders[i].Status == config.OrderPending {
+			update(&orders[i])
+			return config.SaveOrders(orders)
+		}
+	}
+	return nil
+}
+
+// executeOrder sends a conditional order, reusing an earlier transfer with
+// the same customer transaction ID if one was already recorded. The transfer
+// is only created if the quote's rate also meets the condition.
+func executeOrder(o *config.Order, condition config.RateCondition) (int, error) {
+	if existing, err := config.LoadTransfer(o.CustomerTransactionID); err == nil {
+		return existing.ID, nil
+	}
+
+	// The recipient was resolved when the order was placed
+	recipient, err := queries.GetRecipient(apiToken, o.RecipientID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch recipient %d: %w", o.RecipientID, err)
+	}
+
+	// References are rendered when the order is sent, so {{date}} is the payment date
+	seqKey := fmt.Sprintf("recipient-%d", recipient.ID)
+	now := time.Now()
+	reference, err := renderReference(o.Reference, recipient, o.Invoice, seqKey, now, false)
+	if err != nil {
+		return 0, fmt.Errorf("invalid reference: %w", err)
+	}
+
+	transfer, err := executeSend(sendRequest{
+		ProfileID:     o.ProfileID,
+		Recipient:     recipient,
+		Amount:        o.Amount,
+		FixedSource:   o.FixedSource,
+		Currency:      o.Currency,
+		Reference:     reference,
+		SourceAccount: o.SourceAccount,
+		CustomerTxID:  o.CustomerTransactionID,
+		PayIn:         o.PayIn,
+		Details:       o.Details,
+		CheckQuote: func(quote *commands.Quote) error {
+			if !condition.Holds(quote.Rate) {
+				return fmt.Errorf("%w: quote %s is at %.6f", errQuoteRateMissed, quote.ID, quote.Rate)
+			}
+			return nil
+		},
+		ReserveReference: reserveReference(o.Reference, recipient, o.Invoice, seqKey, now),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return transfer.ID, nil
+}
+
+// orderTransactionID derives a stable customer transaction ID for an order,
+// so that retries are deduplicated by Wise
+func orderTransactionID(orderID string) string {
+	name := fmt.Sprintf("wise-cli/order/%s", orderID)
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
+
+func init() {
+	ordersCmd.AddCommand(ordersListCmd)
+	ordersCmd.AddCommand(ordersCancelCmd)
+	ordersCmd.AddCommand(ordersRunCmd)
+
+	ordersListCmd.Flags().Bool("all", false, "Include executed, cancelled and expired orders")
+
+	ordersRunCmd.Flags().BoolP("dry-run", "n", false, "Show which orders would be sent without sending them")
+	ordersRunCmd.Flags().Duration("interval", 0, "Keep checking at this interval until no orders are pending (default: check once)")
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/spf13/cobra"
+)
+
+var quoteCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare payment options of a quote",
+	Long: `Show every pay-in and pay-out combination of a quote side by side, with the amounts, fee breakdown and
+estimated delivery of each. Unavailable options are listed after the available ones with the reason they
+are disabled. Sort by total cost (the source amount paid per unit received) or by speed of delivery.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		sourceCurrency, _ := cmd.Flags().GetString("source-currency")
+		targetCurrency, _ := cmd.Flags().GetString("target-currency")
+		sourceAmount, _ := cmd.Flags().GetFloat64("source-amount")
+		targetAmount, _ := cmd.Flags().GetFloat64("target-amount")
+		sortBy, _ := cmd.Flags().GetString("sort")
+
+		if sourceCurrency == "" {
+			return fmt.Errorf("source-currency is required")
+		}
+		if targetCurrency == "" {
+			return fmt.Errorf("target-currency is required")
+		}
+		if sourceAmount == 0 && targetAmount == 0 {
+			return fmt.Errorf("either source-amount or target-amount is required")
+		}
+		if sourceAmount != 0 && targetAmount != 0 {
+			return fmt.Errorf("only one of source-amount or target-amount can be specified")
+		}
+		if sortBy != "cost" && sortBy != "speed" {
+			return fmt.Errorf("invalid --sort %q: use cost or speed", sortBy)
+		}
+
+		profileID, err := resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		req := queries.GetQuoteRequest{
+			ProfileID:      profileID,
+			SourceCurrency: strings.ToUpper(sourceCurrency),
+			TargetCurrency: strings.ToUpper(targetCurrency),
+		}
+		if sourceAmount != 0 {
+			req.SourceAmount = &sourceAmount
+		}
+		if targetAmount != 0 {
+			req.TargetAmount = &targetAmount
+		}
+
+		quote, err := queries.GetQuote(apiToken, req)
+		if err != nil {
+			return fmt.Errorf("failed to get quote: %w", err)
+		}
+
+		if len(quote.PaymentOptions) == 0 {
+			fmt.Println("No payment options found")
+			return nil
+		}
+
+		options := append([]queries.PaymentOption{}, quote.PaymentOptions...)
+		sortPaymentOptions(options, sortBy)
+
+		if sourceAmount != 0 {
+			fmt.Printf("Sending %.2f %s to %s at %.6f\n\n", sourceAmount, quote.SourceCurrency, quote.TargetCurrency, quote.Rate)
+		} else {
+			fmt.Printf("Receiving %.2f %s from %s at %.6f\n\n", targetAmount, quote.TargetCurrency, quote.SourceCurrency, quote.Rate)
+		}
+		fmt.Printf("%-4s %-20s %-14s %-15s %-15s %-12s %-12s %-12s %-24s\n",
+			"#", "Pay-in", "Pay-out", "Source", "Target", "Fee", "Wise Fee", "Pay-in Fee", "Delivery")
+		fmt.Println(strings.Repeat("-", 135))
+
+		for i, opt := range options {
+			if opt.Disabled {
+				reason := "unavailable"
+				if opt.DisabledReason != nil {
+					reason = opt.DisabledReason.Message
+				}
+				fmt.Printf("%-4d %-20s %-14s %s\n", i+1, opt.PayIn, opt.PayOut, "✗ "+reason)
+				continue
+			}
+
+			fmt.Printf("%-4d %-20s %-14s %-15s %-15s %-12s %-12s %-12s %-24s\n",
+				i+1,
+				opt.PayIn,
+				opt.PayOut,
+				fmt.Sprintf("%.2f %s", opt.SourceAmount, quote.SourceCurrency),
+				fmt.Sprintf("%.2f %s", opt.TargetAmount, quote.TargetCurrency),
+				fmt.Sprintf("%.2f", opt.Fee.Total),
+				fmt.Sprintf("%.2f", opt.Fee.TransferWise),
+				fmt.Sprintf("%.2f", opt.Fee.PayIn),
+				deliveryOf(opt),
+			)
+		}
+
+		fmt.Println("\nUse --pay-in with 'new quote' or 'send-to' to choose a method.")
+		return nil
+	},
+}
+
+// sortPaymentOptions orders available options by cost or delivery time,
+// followed by the disabled ones
+func sortPaymentOptions(options []queries.PaymentOption, by string) {
+	cost := func(opt queries.PaymentOption) float64 {
+		if opt.TargetAmount == 0 {
+			return 0
+		}
+		return opt.SourceAmount / opt.TargetAmount
+	}
+	delivery := func(opt queries.PaymentOption) time.Time {
+		t, err := time.Parse(time.RFC3339, opt.EstimatedDelivery)
+		if err != nil {
+			// Unknown delivery times sort last
+			return time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+		}
+		return t
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		a, b := options[i], options[j]
+		if a.Disabled != b.Disabled {
+			return !a.Disabled
+		}
+		if by == "speed" {
+			if da, db := delivery(a), delivery(b); !da.Equal(db) {
+				return da.Before(db)
+			}
+		}
+		return cost(a) < cost(b)
+	})
+}
+
+// deliveryOf describes when a payment option arrives
+func deliveryOf(opt queries.PaymentOption) string {
+	if opt.FormattedEstimatedDelivery != "" {
+		return opt.FormattedEstimatedDelivery
+	}
+	if t, err := time.Parse(time.RFC3339, opt.EstimatedDelivery); err == nil {
+		return t.Local().Format("2006-01-02 15:04")
+	}
+	return "-"
+}
+
+func init() {
+	quoteCmd.AddCommand(quoteCompareCmd)
+
+	quoteCompareCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+	quoteCompareCmd.Flags().StringP("source-currency", "s", "", "Source currency code (required)")
+	quoteCompareCmd.Flags().StringP("target-currency", "t", "", "Target currency code (required)")
+	quoteCompareCmd.Flags().Float64("source-amount", 0, "Amount in source currency (either this or target-amount)")
+	quoteCompareCmd.Flags().Float64("target-amount", 0, "Amount in target currency (either this or source-amount)")
+	quoteCompareCmd.Flags().String("sort", "cost", "Order options by cost or speed")
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/commands"
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/spf13/cobra"
+)
+
+var quotesCmd = &cobra.Command{
+	Use:   "quotes",
+	Short: "Manage quotes created by the CLI",
+	Long:  "Manage the quotes created by 'new quote', 'send-to' and scheduled payments, which are remembered in quotes.json with their expiry and the transfer they were used for",
+}
+
+var quotesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List created quotes",
+	Long:  "List created quotes, newest first, as live, expired or used. Live quotes can be passed to 'new transfer --quote-uuid'.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, _ := cmd.Flags().GetString("status")
+		status = strings.ToLower(status)
+		if status != "" && status != config.QuoteLive && status != config.QuoteExpired && status != config.QuoteUsed {
+			return fmt.Errorf("invalid --status %q: use live, expired or used", status)
+		}
+
+		quotes, err := config.SortedQuotes()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var shown []config.StoredQuote
+		for _, q := range quotes {
+			if status == "" || q.Status(now) == status {
+				shown = append(shown, q)
+			}
+		}
+
+		if len(shown) == 0 {
+			fmt.Println("No quotes found")
+			return nil
+		}
+
+		fmt.Printf("%-38s %-9s %-15s %-15s %-12s %-8s %-17s %-20s\n", "ID", "Pair", "Source", "Target", "Rate", "Status", "Expires", "Used For")
+		fmt.Println(strings.Repeat("-", 140))
+
+		for _, q := range shown {
+			expires := "-"
+			if !q.ExpiresAt.IsZero() {
+				expires = q.ExpiresAt.Local().Format("2006-01-02 15:04")
+			}
+			usedFor := q.UsedFor()
+			if usedFor == "" {
+				usedFor = "-"
+			}
+
+			fmt.Printf("%-38s %-9s %-15s %-15s %-12s %-8s %-17s %-20s\n",
+				q.ID,
+				q.SourceCurrency+"/"+q.TargetCurrency,
+				fmt.Sprintf("%.2f %s", q.SourceAmount, q.SourceCurrency),
+				fmt.Sprintf("%.2f %s", q.TargetAmount, q.TargetCurrency),
+				fmt.Sprintf("%.6f", q.Rate),
+				q.Status(now),
+				expires,
+				usedFor,
+			)
+		}
+
+		return nil
+	},
+}
+
+// rememberQuote records a created or updated quote in the local quote store
+func rememberQuote(quote *commands.Quote, profileID int, payIn string, targetAccount int) {
+	stored := config.StoredQuote{
+		ID:             quote.ID,
+		ProfileID:      profileID,
+		SourceCurrency: quote.SourceCurrency,
+		TargetCurrency: quote.TargetCurrency,
+		SourceAmount:   quote.SourceAmount,
+		TargetAmount:   quote.TargetAmount,
+		Rate:           quote.Rate,
+		PayIn:          payIn,
+		TargetAccount:  targetAccount,
+		CreatedAt:      parseQuoteTime(quote.CreatedTime),
+		ExpiresAt:      parseQuoteTime(quote.ExpirationTime),
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = time.Now()
+	}
+
+	// Keep what an earlier version of the quote knew
+	if previous, err := config.LookupQuote(quote.ID); err == nil && previous != nil {
+		stored.CreatedAt = previous.CreatedAt
+		if stored.PayIn == "" {
+			stored.PayIn = previous.PayIn
+		}
+		if stored.TargetAccount == 0 {
+			stored.TargetAccount = previous.TargetAccount
+		}
+	}
+
+	if err := config.SetQuote(stored); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save quote: %v\n", err)
+	}
+}
+
+// markQuoteUsed records the transfer, or the balance movement of a
+// conversion, a stored quote was used for
+func markQuoteUsed(quoteID string, transferID, movementID int) {
+	err := config.UpdateQuote(quoteID, func(stored *config.StoredQuote) {
+		stored.UsedAt = time.Now()
+		stored.TransferID = transferID
+		stored.MovementID = movementID
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save quote: %v\n", err)
+	}
+}
+
+// refreshExpiredQuote checks a quote against the local quote store before it
+// is used for a transfer. An expired quote is refreshed by updating it with
+// the recipient, unless refresh is false. Quotes the CLI did not create are
+// passed through unchecked.
+func refreshExpiredQuote(quoteID string, targetAccount int, refresh bool) error {
+	stored, err := config.LookupQuote(quoteID)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		return nil
+	}
+
+	switch stored.Status(time.Now()) {
+	case config.QuoteUsed:
+		fmt.Fprintf(os.Stderr, "Warning: quote %s was already used for %s\n", quoteID, stored.UsedFor())
+		return nil
+	case config.QuoteLive:
+		return nil
+	}
+
+	expired := stored.ExpiresAt.Local().Format("2006-01-02 15:04")
+	if !refresh {
+		return fmt.Errorf("quote %s expired at %s: create a new one with 'wise new quote' or run without --no-refresh", quoteID, expired)
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: quote %s expired at %s, refreshing it\n", quoteID, expired)
+	quote, err := commands.UpdateQuote(apiToken, commands.UpdateQuoteRequest{
+		ProfileID:     stored.ProfileID,
+		QuoteID:       quoteID,
+		TargetAccount: targetAccount,
+	})
+	if err != nil {
+		return fmt.Errorf("quote %s expired at %s and could not be refreshed: %w", quoteID, expired, err)
+	}
+	rememberQuote(quote, stored.ProfileID, stored.PayIn, targetAccount)
+
+	fmt.Printf("✓ Quote refreshed: %.2f %s → %.2f %s at %.6f, expires %s\n",
+		quote.SourceAmount, quote.SourceCurrency, quote.TargetAmount, quote.TargetCurrency, quote.Rate, quote.ExpirationTime)
+	return nil
+}
+
+// attachRecipient updates a quote with the recipient it will be paid to, so
+// that Wise prices it for that recipient, and prints what changed
+func attachRecipient(quote *commands.Quote, profileID, recipientID int, payIn string) (*commands.Quote, error) {
+	fmt.Printf("Updating quote with recipient %d...\n", recipientID)
+	updated, err := commands.UpdateQuote(apiToken, commands.UpdateQuoteRequest{
+		ProfileID:     profileID,
+		QuoteID:       quote.ID,
+		TargetAccount: recipientID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update quote with recipient: %w", err)
+	}
+	rememberQuote(updated, profileID, payIn, recipientID)
+
+	changes := quoteChanges(quote, updated, payIn)
+	if len(changes) == 0 {
+		fmt.Println("Quote unchanged for this recipient")
+	} else {
+		fmt.Println("Quote changed for this recipient:")
+		for _, change := range changes {
+			fmt.Printf("  %s\n", change)
+		}
+	}
+
+	return updated, nil
+}
+
+// quoteChanges describes the differences in rate, amounts, pay-out, fees of
+// the chosen pay-in method and availability of payment options between two
+// versions of a quote
+func quoteChanges(before, after *commands.Quote, payIn string) []string {
+	var changes []string
+	if before.Rate != after.Rate {
+		changes = append(changes, fmt.Sprintf("Rate:     %.6f → %.6f", before.Rate, after.Rate))
+	}
+	if before.SourceAmount != after.SourceAmount {
+		changes = append(changes, fmt.Sprintf("Source:   %.2f → %.2f %s", before.SourceAmount, after.SourceAmount, after.SourceCurrency))
+	}
+	if before.TargetAmount != after.TargetAmount {
+		changes = append(changes, fmt.Sprintf("Target:   %.2f → %.2f %s", before.TargetAmount, after.TargetAmount, after.TargetCurrency))
+	}
+	if before.PayOut != after.PayOut {
+		changes = append(changes, fmt.Sprintf("Pay-out:  %s → %s", orDash(before.PayOut), orDash(after.PayOut)))
+	}
+
+	if payIn == "" {
+		payIn = "BALANCE"
+	}
+	oldOption, newOption := before.PaymentOption(payIn), after.PaymentOption(payIn)
+	if oldOption != nil && newOption != nil && oldOption.Fee.Total != newOption.Fee.Total {
+		changes = append(changes, fmt.Sprintf("Fee:      %.2f → %.2f %s (%s)", oldOption.Fee.Total, newOption.Fee.Total, after.SourceCurrency, payIn))
+	}
+
+	// Options are compared by pay-in and pay-out method
+	disabled := func(q *commands.Quote) map[string]bool {
+		options := make(map[string]bool)
+		for _, opt := range q.PaymentOptions {
+			options[opt.PayIn+" → "+opt.PayOut] = opt.Disabled
+		}
+		return options
+	}
+	oldOptions := disabled(before)
+	for _, opt := range after.PaymentOptions {
+		key := opt.PayIn + " → " + opt.PayOut
+		wasDisabled, existed := oldOptions[key]
+		switch {
+		case opt.Disabled && (!existed || !wasDisabled):
+			reason := "unavailable"
+			if opt.DisabledReason != nil {
+				reason = opt.DisabledReason.Message
+			}
+			changes = append(changes, fmt.Sprintf("✗ %s unavailable: %s", key, reason))
+		case !opt.Disabled && (!existed || wasDisabled):
+			changes = append(changes, fmt.Sprintf("✓ %s now available", key))
+		}
+	}
+
+	return changes
+}
+
+// parseQuoteTime parses a timestamp from a quote, returning the zero time if it is missing or malformed
+func parseQuoteTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func init() {
+	quotesCmd.AddCommand(quotesListCmd)
+
+	quotesListCmd.Flags().String("status", "", "Only show live, expired or used quotes")
+}
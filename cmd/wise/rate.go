@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/spf13/cobra"
+)
+
+// sparklineWidth is the maximum number of characters in a rate sparkline
+const sparklineWidth = 60
+
+// sparkTicks are the bar heights of a sparkline, lowest first
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+var rateCmd = &cobra.Command{
+	Use:   "rate <source-currency> <target-currency>",
+	Short: "Show the current or historical mid-market exchange rate",
+	Long: `Show the mid-market rate between two currencies, without a profile or an amount.
+
+With --from, show the rate history between --from and --to (default: now), grouped by day, hour or minute,
+followed by a sparkline and the lowest, highest and overall change. Current rates are cached for a minute,
+histories for 15 minutes, or a day once they lie entirely in the past. Use --refresh to fetch again.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+		group, _ := cmd.Flags().GetString("group")
+
+		req := queries.RatesRequest{
+			Source: strings.ToUpper(args[0]),
+			Target: strings.ToUpper(args[1]),
+		}
+
+		if fromStr == "" {
+			if toStr != "" {
+				return fmt.Errorf("--to requires --from")
+			}
+
+			rates, err := queries.GetRates(apiToken, req, refresh)
+			if err != nil {
+				return fmt.Errorf("failed to get rate: %w", err)
+			}
+			if len(rates) == 0 {
+				return fmt.Errorf("no rate available for %s to %s", req.Source, req.Target)
+			}
+
+			rate := rates[0]
+			fmt.Printf("1 %s = %.6f %s\n", rate.Source, rate.Rate, rate.Target)
+			fmt.Printf("1 %s = %.6f %s\n", rate.Target, 1/rate.Rate, rate.Source)
+			if t, err := rate.ParsedTime(); err == nil {
+				fmt.Printf("Mid-market rate as of %s\n", t.UTC().Format("2006-01-02 15:04 UTC"))
+			}
+			return nil
+		}
+
+		if group != "day" && group != "hour" && group != "minute" {
+			return fmt.Errorf("invalid --group %q: use day, hour or minute", group)
+		}
+
+		from, _, err := parseDateTime(fromStr)
+		if err != nil {
+			return fmt.Errorf("invalid --from: %w", err)
+		}
+		// Without --to the history ends at the start of the current interval,
+		// so that repeated queries share a cache entry for up to 15 minutes
+		to := time.Now().Truncate(groupInterval(group))
+		if toStr != "" {
+			var dateOnly bool
+			to, dateOnly, err = parseDateTime(toStr)
+			if err != nil {
+				return fmt.Errorf("invalid --to: %w", err)
+			}
+			if dateOnly {
+				// A date includes the whole day
+				to = to.AddDate(0, 0, 1).Add(-time.Second)
+			}
+		}
+		if to.Before(from) {
+			return fmt.Errorf("--to %s is before --from %s", to.Format(time.RFC3339), from.Format(time.RFC3339))
+		}
+		req.From, req.To, req.Group = &from, &to, group
+
+		rates, err := queries.GetRates(apiToken, req, refresh)
+		if err != nil {
+			return fmt.Errorf("failed to get rate history: %w", err)
+		}
+		if len(rates) == 0 {
+			fmt.Println("No rates found")
+			return nil
+		}
+
+		layout := "2006-01-02"
+		if group != "day" {
+			layout = "2006-01-02 15:04"
+		}
+
+		fmt.Printf("%-18s %-12s\n", "Time", req.Source+"/"+req.Target)
+		fmt.Println(strings.Repeat("-", 31))
+		values := make([]float64, len(rates))
+		low, high := 0, 0
+		for i, rate := range rates {
+			when := rate.Time
+			if t, err := rate.ParsedTime(); err == nil {
+				when = t.UTC().Format(layout)
+			}
+			fmt.Printf("%-18s %-12.6f\n", when, rate.Rate)
+
+			values[i] = rate.Rate
+			if rate.Rate < rates[low].Rate {
+				low = i
+			}
+			if rate.Rate > rates[high].Rate {
+				high = i
+			}
+		}
+
+		first, last := rates[0].Rate, rates[len(rates)-1].Rate
+		fmt.Println()
+		fmt.Println(sparkline(values, sparklineWidth))
+		fmt.Printf("Low:    %.6f (%s)\n", rates[low].Rate, dateOf(rates[low].Time))
+		fmt.Printf("High:   %.6f (%s)\n", rates[high].Rate, dateOf(rates[high].Time))
+		fmt.Printf("Change: %+.6f (%+.2f%%)\n", last-first, (last-first)/first*100)
+		return nil
+	},
+}
+
+// groupInterval returns the length of one --group bucket
+func groupInterval(group string) time.Duration {
+	switch group {
+	case "minute":
+		return time.Minute
+	case "hour":
+		return time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// sparkline draws values as a row of bars, averaging neighbouring values
+// when there are more than width of them
+func sparkline(values []float64, width int) string {
+	if len(values) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			start := i * len(values) / width
+			end := (i + 1) * len(values) / width
+			sum := 0.0
+			for _, v := range values[start:end] {
+				sum += v
+			}
+			buckets[i] = sum / float64(end-start)
+		}
+		values = buckets
+	}
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		tick := len(sparkTicks) / 2
+		if high > low {
+			tick = int((v - low) / (high - low) * float64(len(sparkTicks)-1))
+		}
+		b.WriteRune(sparkTicks[tick])
+	}
+	return b.String()
+}
+
+func init() {
+	rateCmd.Flags().String("from", "", "Show the rate history starting at this date (YYYY-MM-DD or RFC 3339)")
+	rateCmd.Flags().String("to", "", "End of the rate history (YYYY-MM-DD or RFC 3339, default: now)")
+	rateCmd.Flags().String("group", "day", "Interval of the rate history: day, hour or minute")
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/spf13/cobra"
+)
+
+// minWatchInterval keeps the watcher from polling the rates endpoint too often
+const minWatchInterval = 10 * time.Second
+
+var rateWatchCmd = &cobra.Command{
+	Use:   "watch [source-currency target-currency]",
+	Short: "Alert when exchange rates cross a threshold",
+	Long: `Poll the mid-market rate and alert when it rises to --above or falls to --below. With a currency pair,
+watch that pair; without, watch every rate watch added with 'rate watch add', all in one process.
+
+An alert prints the rate and runs the watch's --exec command through sh -c with WISE_WATCH_ID, WISE_SOURCE,
+WISE_TARGET, WISE_RATE and WISE_CONDITION set. Each watch fires once when its condition starts to hold and
+is re-armed when the rate moves back, but fires at most once per --cooldown. The alert state is kept in
+rate-watch-state.json, so restarting the watcher does not repeat alerts.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 2 {
+			return fmt.Errorf("expected a source and target currency, or none to run the configured watches")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		interval, _ := cmd.Flags().GetDuration("interval")
+		cooldown, _ := cmd.Flags().GetDuration("cooldown")
+		once, _ := cmd.Flags().GetBool("once")
+
+		if interval < minWatchInterval {
+			return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+		}
+
+		var watches []config.RateWatch
+		if len(args) == 2 {
+			watch, err := rateWatchFromFlags(cmd, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			watches = []config.RateWatch{watch}
+		} else {
+			var err error
+			watches, err = config.LoadRateWatches()
+			if err != nil {
+				return err
+			}
+			if len(watches) == 0 {
+				return fmt.Errorf("no rate watches configured: add one with 'wise rate watch add' or pass a currency pair")
+			}
+		}
+
+		if once {
+			return checkRateWatches(watches, cooldown, true)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fmt.Printf("Watching %d rates every %s (Ctrl-C to stop)\n", len(watches), interval)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := checkRateWatches(watches, cooldown, false); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+var rateWatchAddCmd = &cobra.Command{
+	Use:   "add <source-currency> <target-currency>",
+	Short: "Add a rate watch",
+	Long:  "Add a rate watch to rate-watches.json, to be run by 'wise rate watch' together with the other watches",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		watch, err := rateWatchFromFlags(cmd, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		if id, _ := cmd.Flags().GetString("id"); id != "" {
+			watch.ID = id
+		}
+
+		release, err := config.AcquireLock("rate-watch")
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		watches, err := config.LoadRateWatches()
+		if err != nil {
+			return err
+		}
+		for _, w := range watches {
+			if w.ID == watch.ID {
+				return fmt.Errorf("rate watch %s already exists", watch.ID)
+			}
+		}
+
+		watches = append(watches, watch)
+		if err := config.SaveRateWatches(watches); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Watching %s/%s %s (ID: %s)\n", watch.Source, watch.Target, watch.Condition(), watch.ID)
+		return nil
+	},
+}
+
+var rateWatchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List rate watches",
+	Long:  "List the configured rate watches with their alert state and last seen rate",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		watches, err := config.LoadRateWatches()
+		if err != nil {
+			return err
+		}
+
+		if len(watches) == 0 {
+			fmt.Println("No rate watches found")
+			return nil
+		}
+
+		states, err := config.LoadRateWatchStates()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%-24s %-9s %-22s %-10s %-12s %-30s\n", "ID", "Pair", "Condition", "State", "Last Rate", "Exec")
+		fmt.Println(strings.Repeat("-", 112))
+
+		for _, w := range watches {
+			state := states[w.ID]
+			status := "armed"
+			if state.Triggered {
+				status = "triggered"
+			}
+			lastRate := "-"
+			if !state.CheckedAt.IsZero() {
+				lastRate = strconv.FormatFloat(state.LastRate, 'f', 6, 64)
+			}
+
+			fmt.Printf("%-24s %-9s %-22s %-10s %-12s %-30s\n",
+				w.ID,
+				w.Source+"/"+w.Target,
+				w.Condition(),
+				status,
+				lastRate,
+				orDash(w.Exec),
+			)
+		}
+
+		return nil
+	},
+}
+
+var rateWatchRmCmd = &cobra.Command{
+	Use:   "rm <watch-id>",
+	Short: "Remove a rate watch",
+	Long:  "Remove a rate watch and its alert state",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		release, err := config.AcquireLock("rate-watch")
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		watches, err := config.LoadRateWatches()
+		if err != nil {
+			return err
+		}
+
+		remaining := watches[:0]
+		found := false
+		for _, w := range watches {
+			if w.ID == args[0] {
+				found = true
+				continue
+			}
+			remaining = append(remaining, w)
+		}
+		if !found {
+			return fmt.Errorf("rate watch not found: %s", args[0])
+		}
+
+		if err := config.SaveRateWatches(remaining); err != nil {
+			return err
+		}
+
+		states, err := config.LoadRateWatchStates()
+		if err != nil {
+			return err
+		}
+		delete(states, args[0])
+		if err := config.SaveRateWatchStates(states); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Removed rate watch %s\n", args[0])
+		return nil
+	},
+}
+
+// rateWatchFromFlags builds a watch for a currency pair from --above, --below and --exec
+func rateWatchFromFlags(cmd *cobra.Command, source, target string) (config.RateWatch, error) {
+	execCmd, _ := cmd.Flags().GetString("exec")
+
+	watch := config.RateWatch{
+		Source:    strings.ToUpper(source),
+		Target:    strings.ToUpper(target),
+		Exec:      execCmd,
+		CreatedAt: time.Now(),
+	}
+	if cmd.Flags().Changed("above") {
+		above, _ := cmd.Flags().GetFloat64("above")
+		watch.Above = &above
+	}
+	if cmd.Flags().Changed("below") {
+		below, _ := cmd.Flags().GetFloat64("below")
+		watch.Below = &below
+	}
+	if watch.Above == nil && watch.Below == nil {
+		return watch, fmt.Errorf("--above or --below is required")
+	}
+
+	watch.ID = config.RateWatchID(watch.Source, watch.Target, watch.Above, watch.Below)
+	return watch, nil
+}
+
+// rateAlert is a watch whose condition started to hold
+type rateAlert struct {
+	watch config.RateWatch
+	rate  float64
+}
+
+// checkRateWatches fetches the rate of every watched pair once, updates the
+// alert state and fires the watches whose condition started to hold. With
+// verbose set, every watch's rate is printed, not only alerts.
+func checkRateWatches(watches []config.RateWatch, cooldown time.Duration, verbose bool) error {
+	// Each pair is fetched once, however many watches it has
+	rates := make(map[string]float64)
+	for _, w := range watches {
+		pair := w.Source + "/" + w.Target
+		if _, ok := rates[pair]; ok {
+			continue
+		}
+		current, err := queries.GetRates(apiToken, queries.RatesRequest{Source: w.Source, Target: w.Target}, true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get %s rate: %v\n", pair, err)
+			continue
+		}
+		if len(current) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: no rate available for %s\n", pair)
+			continue
+		}
+		rates[pair] = current[0].Rate
+	}
+
+	release, err := config.AcquireLock("rate-watch")
+	if err != nil {
+		return err
+	}
+
+	states, err := config.LoadRateWatchStates()
+	if err != nil {
+		release()
+		return err
+	}
+
+	now := time.Now()
+	var alerts []rateAlert
+	for _, w := range watches {
+		rate, ok := rates[w.Source+"/"+w.Target]
+		if !ok {
+			continue
+		}
+
+		state := states[w.ID]
+		state.LastRate, state.CheckedAt = rate, now
+
+		holds := w.Holds(rate)
+		switch {
+		case holds && !state.Triggered && now.Sub(state.FiredAt) >= cooldown:
+			state.Triggered, state.FiredAt = true, now
+			alerts = append(alerts, rateAlert{watch: w, rate: rate})
+		case !holds && state.Triggered:
+			state.Triggered = false
+			fmt.Printf("%s %s/%s at %.6f, %s re-armed\n", now.Format("15:04:05"), w.Source, w.Target, rate, w.ID)
+		case verbose && holds && state.Triggered:
+			fmt.Printf("%s/%s at %.6f, %s already alerted\n", w.Source, w.Target, rate, w.ID)
+		case verbose && holds:
+			fmt.Printf("%s/%s at %.6f, %s cooling down until %s\n", w.Source, w.Target, rate, w.ID, state.FiredAt.Add(cooldown).Format("15:04:05"))
+		case verbose:
+			fmt.Printf("%s/%s at %.6f, %s not met (%s)\n", w.Source, w.Target, rate, w.ID, w.Condition())
+		}
+		states[w.ID] = state
+	}
+
+	err = config.SaveRateWatchStates(states)
+	release()
+	if err != nil {
+		return err
+	}
+
+	for _, alert := range alerts {
+		fireRateAlert(alert.watch, alert.rate)
+	}
+	return nil
+}
+
+// fireRateAlert prints an alert and runs the watch's command
+func fireRateAlert(w config.RateWatch, rate float64) {
+	fmt.Printf("%s ALERT %s/%s at %.6f (%s, %s)\n", time.Now().Format("15:04:05"), w.Source, w.Target, rate, w.Condition(), w.ID)
+	if w.Exec == "" {
+		return
+	}
+
+	c := exec.Command("sh", "-c", w.Exec)
+	c.Stdout, c.Stderr = os.Stdout, os.Stderr
+	c.Env = append(os.Environ(),
+		"WISE_WATCH_ID="+w.ID,
+		"WISE_SOURCE="+w.Source,
+		"WISE_TARGET="+w.Target,
+		"WISE_RATE="+strconv.FormatFloat(rate, 'f', 6, 64),
+		"WISE_CONDITION="+w.Condition(),
+	)
+	if err := c.Run(); err != nil {
+		fmt.Printf("✗ %s: command failed: %v\n", w.ID, err)
+	}
+}
+
+func init() {
+	rateCmd.AddCommand(rateWatchCmd)
+	rateWatchCmd.AddCommand(rateWatchAddCmd)
+	rateWatchCmd.AddCommand(rateWatchListCmd)
+	rateWatchCmd.AddCommand(rateWatchRmCmd)
+
+	for _, c := range []*cobra.Command{rateWatchCmd, rateWatchAddCmd} {
+		c.Flags().Float64("above", 0, "Fire when the rate rises to or above this value")
+		c.Flags().Float64("below", 0, "Fire when the rate falls to or below this value")
+		c.Flags().String("exec", "", "Command to run through sh -c when the watch fires (optional)")
+	}
+	rateWatchAddCmd.Flags().String("id", "", "Watch ID (default: derived from pair and condition, e.g. eur-usd-above-1.12)")
+
+	rateWatchCmd.Flags().Duration("interval", time.Minute, "How often to check the rates")
+	rateWatchCmd.Flags().Duration("cooldown", time.Hour, "Minimum time between two alerts of the same watch")
+	rateWatchCmd.Flags().Bool("once", false, "Check once and exit, e.g. from cron")
+}
@@ -0,0 +1,565 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/commands"
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/spf13/cobra"
+)
+
+var recipientCmd = &cobra.Command{
+	Use:   "recipient",
+	Short: "Inspect and remove a single recipient",
+	Long:  "Show the full details of a recipient or delete it",
+}
+
+var recipientShowCmd = &cobra.Command{
+	Use:   "show <recipient-id-name-or-alias>",
+	Short: "Show recipient details",
+	Long:  "Show all details fields and the address of a recipient, looked up by ID, alias or name",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+
+		recipient, err := lookupRecipient(profileID, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Format output
+		fmt.Println("Recipient Details:")
+		fmt.Println("==================")
+		fmt.Printf("ID:                %d\n", recipient.ID)
+		fmt.Printf("Name:              %s\n", recipient.Name.FullName)
+		fmt.Printf("Currency:          %s\n", recipient.Currency)
+		fmt.Printf("Country:           %s\n", recipient.Country)
+		fmt.Printf("Type:              %s\n", recipient.Type)
+		fmt.Printf("Legal Entity Type: %s\n", recipient.LegalEntityType)
+		fmt.Printf("Account Summary:   %s\n", recipient.AccountSummary)
+		if recipient.LongAccountSummary != "" {
+			fmt.Printf("Long Summary:      %s\n", recipient.LongAccountSummary)
+		}
+		fmt.Printf("Active:            %v\n", recipient.Active)
+		fmt.Printf("Owned by Customer: %v\n", recipient.OwnedByCustomer)
+		fmt.Printf("Profile ID:        %d\n", recipient.ProfileID)
+		fmt.Printf("Hash:              %s\n", recipient.Hash)
+
+		details := recipient.Details.Flatten()
+		var detailKeys, addressKeys []string
+		for key := range details {
+			if strings.HasPrefix(key, "address.") {
+				addressKeys = append(addressKeys, key)
+			} else {
+				detailKeys = append(detailKeys, key)
+			}
+		}
+		sort.Strings(detailKeys)
+		sort.Strings(addressKeys)
+
+		if len(detailKeys) > 0 {
+			fmt.Println("\nDetails:")
+			for _, key := range detailKeys {
+				fmt.Printf("  %-28s %s\n", key+":", details[key])
+			}
+		}
+
+		if len(addressKeys) > 0 {
+			fmt.Println("\nAddress:")
+			for _, key := range addressKeys {
+				fmt.Printf("  %-28s %s\n", strings.TrimPrefix(key, "address.")+":", details[key])
+			}
+		}
+
+		return nil
+	},
+}
+
+var recipientDeleteCmd = &cobra.Command{
+	Use:   "delete <recipient-id>",
+	Short: "Delete a recipient",
+	Long:  "Deactivate a recipient account and drop cached recipient lists",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		recipientID, err := strconv.Atoi(args[0])
+		if err != nil || recipientID <= 0 {
+			return fmt.Errorf("invalid recipient ID: %s", args[0])
+		}
+
+		if err := deleteRecipient(recipientID); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Deleted recipient %d\n", recipientID)
+		return nil
+	},
+}
+
+var recipientsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Find recipients nobody has paid recently",
+	Long: `List recipients whose last transfer is older than the given period, cross-referencing the full transfer history.
+Use --delete to remove them. Recipients an alias, a schedule or a pending order still points to are kept.
+
+Recipients that were never paid are kept unless --include-never-paid is given: Wise does not report when a
+recipient was created, so one added in the web app last week looks the same as one that was never used.
+With --include-never-paid, recipients created with the CLI inside the period, and any recipient with a
+higher ID, are still kept.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		unusedFor, _ := cmd.Flags().GetString("unused-for")
+		deleteUnused, _ := cmd.Flags().GetBool("delete")
+		yes, _ := cmd.Flags().GetBool("yes")
+		includeNeverPaid, _ := cmd.Flags().GetBool("include-never-paid")
+
+		age, err := parseAge(unusedFor)
+		if err != nil {
+			return fmt.Errorf("invalid --unused-for: %w", err)
+		}
+
+		profileID, err = resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		recipients, err := queries.ListRecipientsWithRefresh(apiToken, queries.ListRecipientsRequest{
+			ProfileID: profileID,
+		}, refresh)
+		if err != nil {
+			return fmt.Errorf("failed to list recipients: %w", err)
+		}
+
+		// The whole history is read, so that recipients last paid before the
+		// period can be told apart from recipients that were never paid
+		until := time.Now()
+		since := until.Add(-age)
+		lastPaid := make(map[int]time.Time)
+
+		err = eachTransfer(queries.ListTransfersRequest{
+			ProfileID: profileID,
+			Until:     &until,
+		}, func(t queries.Transfer) error {
+			created, err := parseTransferTime(t.Created)
+			if err != nil {
+				return nil
+			}
+			if created.After(lastPaid[t.TargetAccount]) {
+				lastPaid[t.TargetAccount] = created
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list transfers: %w", err)
+		}
+
+		newSince, err := newRecipientsSince(since)
+		if err != nil {
+			return err
+		}
+		referenced, err := referencedRecipients(recipients)
+		if err != nil {
+			return err
+		}
+
+		var unused []queries.Recipient
+		kept, neverPaid := 0, 0
+		for _, r := range recipients {
+			paidAt, paid := lastPaid[r.ID]
+			if paid && !paidAt.Before(since) {
+				continue
+			}
+			if !paid && !includeNeverPaid {
+				neverPaid++
+				kept++
+				continue
+			}
+			if newSince != 0 && r.ID >= newSince {
+				fmt.Fprintf(os.Stderr, "Keeping %d %s: created since %s\n", r.ID, r.Name.FullName, since.Format("2006-01-02"))
+				kept++
+				continue
+			}
+			if reason, ok := referenced[r.ID]; ok {
+				fmt.Fprintf(os.Stderr, "Keeping %d %s: used by %s\n", r.ID, r.Name.FullName, reason)
+				kept++
+				continue
+			}
+			unused = append(unused, r)
+		}
+
+		if neverPaid > 0 {
+			fmt.Fprintf(os.Stderr, "Keeping %d recipient(s) that were never paid and may be new: pass --include-never-paid to prune them\n", neverPaid)
+		}
+
+		if len(unused) == 0 {
+			if kept > 0 {
+				fmt.Printf("No recipients to prune: the %d unpaid since %s are new or still in use\n", kept, since.Format("2006-01-02"))
+				return nil
+			}
+			fmt.Printf("All %d recipients were paid since %s\n", len(recipients), since.Format("2006-01-02"))
+			return nil
+		}
+
+		// Format output
+		fmt.Printf("%d of %d recipients have not been paid since %s:\n\n", len(unused), len(recipients), since.Format("2006-01-02"))
+		fmt.Printf("%-10s %-30s %-10s %-12s %-30s\n", "ID", "Name", "Currency", "Last Paid", "Account Summary")
+		fmt.Println(strings.Repeat("-", 98))
+		for _, r := range unused {
+			lastPaidAt := "never"
+			if paidAt, ok := lastPaid[r.ID]; ok {
+				lastPaidAt = paidAt.Format("2006-01-02")
+			}
+			fmt.Printf("%-10d %-30s %-10s %-12s %-30s\n", r.ID, r.Name.FullName, r.Currency, lastPaidAt, r.AccountSummary)
+		}
+
+		if !deleteUnused {
+			fmt.Println("\nRun with --delete to remove these recipients")
+			return nil
+		}
+
+		if !yes {
+			if !stdinIsTerminal() {
+				return fmt.Errorf("refusing to delete %d recipient(s) without confirmation: pass --yes", len(unused))
+			}
+			fmt.Printf("\nDelete these %d recipients? [y/N] ", len(unused))
+			answer, _ := stdinReader.ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				fmt.Println("Nothing deleted")
+				return nil
+			}
+		}
+
+		fmt.Println()
+		failed := 0
+		for _, r := range unused {
+			if err := deleteRecipient(r.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "✗ %d %s: %v\n", r.ID, r.Name.FullName, err)
+				failed++
+				continue
+			}
+			fmt.Printf("✓ Deleted %d %s\n", r.ID, r.Name.FullName)
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to delete %d recipient(s)", failed)
+		}
+
+		return nil
+	},
+}
+
+var recipientsDupesCmd = &cobra.Command{
+	Use:   "dupes",
+	Short: "List duplicate recipients",
+	Long:  "List clusters of recipients that pay the same account, matched by hash or by normalized IBAN, sort code and account, routing and account number, or email",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+
+		profileID, err := resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		recipients, err := queries.ListRecipientsWithRefresh(apiToken, queries.ListRecipientsRequest{
+			ProfileID: profileID,
+		}, refresh)
+		if err != nil {
+			return fmt.Errorf("failed to list recipients: %w", err)
+		}
+
+		clusters := duplicateClusters(recipients)
+		if len(clusters) == 0 {
+			fmt.Printf("No duplicates among %d recipients\n", len(recipients))
+			return nil
+		}
+
+		for i, cluster := range clusters {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s (%d recipients)\n", cluster.key, len(cluster.recipients))
+			for _, r := range cluster.recipients {
+				fmt.Printf("  %-10d %-30s %-10s %s\n", r.ID, r.Name.FullName, r.Currency, r.AccountSummary)
+			}
+		}
+
+		return nil
+	},
+}
+
+// recipientCluster is a group of recipients that pay the same account
+type recipientCluster struct {
+	key        string
+	recipients []queries.Recipient
+}
+
+// duplicateClusters groups recipients sharing a hash or account identifier,
+// merging groups that overlap so each recipient appears in one cluster
+func duplicateClusters(recipients []queries.Recipient) []recipientCluster {
+	// Union-find over recipient indexes
+	parent := make([]int, len(recipients))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	seen := make(map[string]int)
+	link := func(key string, i int) {
+		if key == "" {
+			return
+		}
+		if j, ok := seen[key]; ok {
+			parent[find(i)] = find(j)
+			return
+		}
+		seen[key] = i
+	}
+	for i := range recipients {
+		if recipients[i].Hash != "" {
+			link("hash:"+recipients[i].Hash, i)
+		}
+		link(recipients[i].AccountKey(), i)
+	}
+
+	groups := make(map[int][]queries.Recipient)
+	for i := range recipients {
+		root := find(i)
+		groups[root] = append(groups[root], recipients[i])
+	}
+
+	var clusters []recipientCluster
+	for _, group := range groups {
+		if len(group) < 2 {
+			continue
+		}
+		key := group[0].AccountKey()
+		if key == "" {
+			key = "hash " + group[0].Hash
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+		clusters = append(clusters, recipientCluster{key: key, recipients: group})
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].key < clusters[j].key })
+
+	return clusters
+}
+
+// checkDuplicateRecipient looks for existing recipients with the same account
+// identifier, returning an error unless duplicates are explicitly allowed
+func checkDuplicateRecipient(profileID int, currency string, details map[string]interface{}, allowDuplicate bool) error {
+	key := queries.AccountKey(currency, details)
+	if key == "" {
+		return nil
+	}
+
+	existing, err := queries.ListRecipientsWithRefresh(apiToken, queries.ListRecipientsRequest{
+		ProfileID: profileID,
+		Currency:  currency,
+	}, refresh)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate recipients: %w", err)
+	}
+
+	for _, r := range existing {
+		if r.AccountKey() != key {
+			continue
+		}
+		if allowDuplicate {
+			fmt.Fprintf(os.Stderr, "Warning: recipient %d (%s) already pays this account\n", r.ID, r.Name.FullName)
+			return nil
+		}
+		return fmt.Errorf("recipient %d (%s) already pays this account: use --allow-duplicate to create another one", r.ID, r.Name.FullName)
+	}
+
+	return nil
+}
+
+// lookupRecipient finds a recipient by numeric ID, alias or name
+func lookupRecipient(profileID int, idOrName string) (*queries.Recipient, error) {
+	if recipientID, err := strconv.Atoi(idOrName); err == nil {
+		recipient, err := queries.GetRecipient(apiToken, recipientID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recipient: %w", err)
+		}
+		return recipient, nil
+	}
+
+	alias, err := config.LookupAlias(idOrName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aliases: %w", err)
+	}
+	if alias != nil {
+		recipient, err := queries.GetRecipient(apiToken, alias.RecipientID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recipient: %w", err)
+		}
+		return recipient, nil
+	}
+
+	profileID, err = resolveProfileID(profileID)
+	if err != nil {
+		return nil, err
+	}
+	return resolveRecipient(profileID, nil, idOrName, "")
+}
+
+// deleteRecipient deletes a recipient, invalidates cached recipient lists and
+// warns about aliases that still point at it
+func deleteRecipient(recipientID int) error {
+	if err := commands.DeleteRecipient(apiToken, recipientID); err != nil {
+		return fmt.Errorf("failed to delete recipient: %w", err)
+	}
+
+	invalidateRecipients()
+
+	if aliases, err := config.SortedAliases(); err == nil {
+		for _, a := range aliases {
+			if a.RecipientID == recipientID {
+				fmt.Fprintf(os.Stderr, "Warning: alias %s still points to deleted recipient %d (remove it with 'wise alias rm %s')\n", a.Name, recipientID, a.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// recordRecipientCreated remembers when a recipient was created, so that
+// 'recipients prune' does not remove it before it had a chance to be paid
+func recordRecipientCreated(recipientID int) {
+	if err := config.RecordRecipientCreated(recipientID, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record recipient: %v\n", err)
+	}
+}
+
+// newRecipientsSince returns the lowest ID of the recipients known to be
+// created after since, or 0 if there are none. Wise hands out recipient IDs
+// in increasing order, so any recipient with this ID or higher is newer too.
+func newRecipientsSince(since time.Time) (int, error) {
+	created, err := config.LoadRecipientCreations()
+	if err != nil {
+		return 0, err
+	}
+
+	lowest := 0
+	for id, at := range created {
+		if at.After(since) && (lowest == 0 || id < lowest) {
+			lowest = id
+		}
+	}
+	return lowest, nil
+}
+
+// referencedRecipients maps the recipients that aliases, schedules and
+// pending orders point to onto a description of the first one found
+func referencedRecipients(recipients []queries.Recipient) (map[int]string, error) {
+	referenced := make(map[int]string)
+	add := func(id int, reason string) {
+		if _, ok := referenced[id]; !ok && id != 0 {
+			referenced[id] = reason
+		}
+	}
+
+	aliases, err := config.SortedAliases()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range aliases {
+		add(a.RecipientID, "alias "+a.Name)
+	}
+
+	schedules, err := config.LoadSchedules()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range schedules {
+		if alias, err := config.LookupAlias(s.Recipient); err == nil && alias != nil {
+			add(alias.RecipientID, "schedule "+s.ID)
+			continue
+		}
+		var candidates []queries.Recipient
+		for _, r := range recipients {
+			if s.Currency == "" || strings.EqualFold(r.Currency, s.Currency) {
+				candidates = append(candidates, r)
+			}
+		}
+		if r := matchRecipientName(candidates, s.Recipient); r != nil {
+			add(r.ID, "schedule "+s.ID)
+		}
+	}
+
+	orders, err := config.LoadOrders()
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range orders {
+		if o.Status == config.OrderPending {
+			add(o.RecipientID, "pending order "+o.ID)
+		}
+	}
+
+	return referenced, nil
+}
+
+// parseAge parses a duration that may also be given in days, e.g. "365d" or "12h"
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid number of days: %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive: %q", s)
+	}
+	return d, nil
+}
+
+func init() {
+	recipientCmd.AddCommand(recipientShowCmd)
+	recipientCmd.AddCommand(recipientDeleteCmd)
+	recipientsCmd.AddCommand(recipientsPruneCmd)
+	recipientsCmd.AddCommand(recipientsDupesCmd)
+
+	recipientShowCmd.Flags().IntP("profile-id", "p", 0, "Profile ID used for name lookups (optional, uses default if not set)")
+
+	recipientsDupesCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+
+	recipientsPruneCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+	recipientsPruneCmd.Flags().String("unused-for", "365d", "Period without transfers, in days (e.g. 365d) or as a Go duration")
+	recipientsPruneCmd.Flags().Bool("delete", false, "Delete the unused recipients instead of only listing them")
+	recipientsPruneCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+	recipientsPruneCmd.Flags().Bool("include-never-paid", false, "Also prune recipients without any transfer, which may have been added recently outside the CLI")
+}
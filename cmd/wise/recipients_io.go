@@ -0,0 +1,328 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dhamidi/wise-cli/commands"
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/dhamidi/wise-cli/validation"
+	"github.com/spf13/cobra"
+)
+
+// recipientBaseColumns are the fixed leading columns of a recipient CSV export
+var recipientBaseColumns = []string{"id", "name", "currency", "country", "type", "legalEntityType", "accountSummary", "hash"}
+
+var recipientsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export recipients as CSV or JSON",
+	Long:  "Export recipient accounts. CSV output flattens the details into details.<field> columns and can be read back with 'recipients import'.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		currency, _ := cmd.Flags().GetString("currency")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		profileID, err := resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		recipients, err := queries.ListRecipientsWithRefresh(apiToken, queries.ListRecipientsRequest{
+			ProfileID: profileID,
+			Currency:  currency,
+		}, refresh)
+		if err != nil {
+			return fmt.Errorf("failed to list recipients: %w", err)
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		switch format {
+		case "csv":
+			err = writeRecipientsCSV(w, recipients)
+		case "json":
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			err = encoder.Encode(recipients)
+		default:
+			return fmt.Errorf("unsupported format %q: use csv or json", format)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+
+		if output != "" {
+			fmt.Printf("✓ Exported %d recipients to %s\n", len(recipients), output)
+		}
+		return nil
+	},
+}
+
+var recipientsImportCmd = &cobra.Command{
+	Use:   "import <file.csv>",
+	Short: "Import recipients from CSV",
+	Long:  "Create recipients from a CSV file with name, currency and type columns plus details.<field> columns, as written by 'recipients export'. Rows whose hash or normalized account identifier matches an existing recipient are skipped.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		profileID, err := resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open import file: %w", err)
+		}
+		defer f.Close()
+
+		rows, err := readRecipientsCSV(f)
+		if err != nil {
+			return err
+		}
+
+		existing, err := queries.ListRecipientsWithRefresh(apiToken, queries.ListRecipientsRequest{
+			ProfileID: profileID,
+		}, refresh)
+		if err != nil {
+			return fmt.Errorf("failed to list recipients: %w", err)
+		}
+
+		// Index existing recipients by hash and account identifier for duplicate detection
+		byHash := make(map[string]int)
+		byAccount := make(map[string]int)
+		for _, r := range existing {
+			if r.Hash != "" {
+				byHash[r.Hash] = r.ID
+			}
+			if key := r.AccountKey(); key != "" {
+				byAccount[key] = r.ID
+			}
+		}
+
+		created, skipped, failed := 0, 0, 0
+		for _, row := range rows {
+			label := fmt.Sprintf("row %d (%s)", row.line, row.name)
+
+			if id, ok := byHash[row.hash]; ok && row.hash != "" {
+				fmt.Printf("- %s: skipped, matches recipient %d\n", label, id)
+				skipped++
+				continue
+			}
+			accountKey := queries.AccountKey(row.currency, row.details)
+			if id, ok := byAccount[accountKey]; ok && accountKey != "" {
+				fmt.Printf("- %s: skipped, matches recipient %d\n", label, id)
+				skipped++
+				continue
+			}
+
+			if row.name == "" || row.currency == "" || row.recipientType == "" {
+				fmt.Fprintf(os.Stderr, "✗ %s: name, currency and type are required\n", label)
+				failed++
+				continue
+			}
+			if err := validation.RecipientDetails(row.details); err != nil {
+				fmt.Fprintf(os.Stderr, "✗ %s: %v\n", label, err)
+				failed++
+				continue
+			}
+
+			if dryRun {
+				fmt.Printf("+ %s: would create %s %s recipient\n", label, row.currency, row.recipientType)
+				created++
+				continue
+			}
+
+			ownedByCustomer := false
+			recipient, err := commands.NewRecipient(apiToken, commands.NewRecipientRequest{
+				ProfileID:         profileID,
+				Currency:          row.currency,
+				Type:              row.recipientType,
+				AccountHolderName: row.name,
+				OwnedByCustomer:   &ownedByCustomer,
+				Details:           row.details,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "✗ %s: %v\n", label, err)
+				failed++
+				continue
+			}
+
+			fmt.Printf("+ %s: created recipient %d\n", label, recipient.ID)
+			recordRecipientCreated(recipient.ID)
+			if recipient.Hash != "" {
+				byHash[recipient.Hash] = recipient.ID
+			}
+			if accountKey != "" {
+				byAccount[accountKey] = recipient.ID
+			}
+			created++
+		}
+
+		if created > 0 && !dryRun {
+			invalidateRecipients()
+		}
+
+		fmt.Printf("\n%d created, %d skipped, %d failed\n", created, skipped, failed)
+		if failed > 0 {
+			return fmt.Errorf("%d row(s) failed to import", failed)
+		}
+
+		return nil
+	},
+}
+
+// writeRecipientsCSV writes recipients with their details flattened into columns
+func writeRecipientsCSV(w io.Writer, recipients []queries.Recipient) error {
+	flattened := make([]map[string]string, len(recipients))
+	detailColumns := make(map[string]bool)
+	for i, r := range recipients {
+		flattened[i] = r.Details.Flatten()
+		for key := range flattened[i] {
+			detailColumns[key] = true
+		}
+	}
+
+	detailKeys := make([]string, 0, len(detailColumns))
+	for key := range detailColumns {
+		detailKeys = append(detailKeys, key)
+	}
+	sort.Strings(detailKeys)
+
+	header := append([]string{}, recipientBaseColumns...)
+	for _, key := range detailKeys {
+		header = append(header, "details."+key)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for i, r := range recipients {
+		record := []string{
+			strconv.Itoa(r.ID),
+			r.Name.FullName,
+			r.Currency,
+			r.Country,
+			r.Type,
+			r.LegalEntityType,
+			r.AccountSummary,
+			r.Hash,
+		}
+		for _, key := range detailKeys {
+			record = append(record, flattened[i][key])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// recipientRow is one recipient read from an import file
+type recipientRow struct {
+	line          int
+	name          string
+	currency      string
+	recipientType string
+	hash          string
+	details       map[string]interface{}
+}
+
+// readRecipientsCSV parses an import file; columns are matched by header name
+func readRecipientsCSV(r io.Reader) ([]recipientRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"name", "currency", "type"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV is missing the %q column", required)
+		}
+	}
+
+	var rows []recipientRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV line %d: %w", line, err)
+		}
+
+		get := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		details := make(map[string]string)
+		for column := range columns {
+			if key, ok := strings.CutPrefix(column, "details."); ok {
+				details[key] = get(column)
+			}
+		}
+
+		rows = append(rows, recipientRow{
+			line:          line,
+			name:          get("name"),
+			currency:      strings.ToUpper(get("currency")),
+			recipientType: get("type"),
+			hash:          get("hash"),
+			details:       nestValues(details),
+		})
+	}
+
+	return rows, nil
+}
+
+func init() {
+	recipientsCmd.AddCommand(recipientsExportCmd)
+	recipientsCmd.AddCommand(recipientsImportCmd)
+
+	recipientsExportCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+	recipientsExportCmd.Flags().StringP("currency", "c", "", "Only export recipients in this currency (optional)")
+	recipientsExportCmd.Flags().StringP("format", "f", "csv", "Output format: csv or json")
+	recipientsExportCmd.Flags().StringP("output", "o", "", "Write to a file instead of stdout (optional)")
+
+	recipientsImportCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+	recipientsImportCmd.Flags().BoolP("dry-run", "n", false, "Report what would be created without creating anything")
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dhamidi/wise-cli/filter"
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/spf13/cobra"
+)
+
+// reportDimensions are the fields transfers can be grouped by, with their table widths
+var reportDimensions = map[string]int{
+	"recipient": 30,
+	"currency":  8,
+	"month":     7,
+	"status":    24,
+}
+
+// reportGroup sums the transfers sharing the same group values and currency pair
+type reportGroup struct {
+	keys           []string
+	sourceCurrency string
+	targetCurrency string
+	count          int
+	source         float64
+	target         float64
+	fees           float64
+	weightedRate   float64 // sum of rate times source amount
+}
+
+// averageRate returns the recorded rates averaged by source amount
+func (g *reportGroup) averageRate() float64 {
+	if g.source == 0 {
+		return 0
+	}
+	return g.weightedRate / g.source
+}
+
+// reportEntry is a group as written by --format json
+type reportEntry struct {
+	Group       map[string]string `json:"group"`
+	Count       int               `json:"count"`
+	Source      queries.Money     `json:"source"`
+	Target      queries.Money     `json:"target"`
+	Fees        queries.Money     `json:"fees"`
+	AverageRate float64           `json:"averageRate"`
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report [filter]",
+	Short: "Summarize transfers by recipient, currency, month or status",
+	Long: `Sum source and target amounts and fees, and average the exchange rate, over the transfers in a date range,
+grouped by one or more of recipient, currency (what the recipient receives), month and status. Amounts in
+different currencies are never added up: each group is split further by source and target currency.
+
+An optional filter expression narrows the transfers, with the same syntax and fields as 'wise transfers'.
+Cancelled, refunded and bounced transfers are left out unless --all is given.
+
+Fees recorded by 'transfers export' are used as they are; other fees are derived from the rate, or taken
+from each transfer's quote with --quote-fees, which fetches one quote per transfer the first time.
+
+  wise report --since 2026-07-01 --until 2026-09-30 --group-by recipient,currency
+  wise report 'recipient~"contractor"' --days 365 --group-by month --format csv`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		all, _ := cmd.Flags().GetBool("all")
+		quoteFees, _ := cmd.Flags().GetBool("quote-fees")
+
+		if format != "table" && format != "json" && format != "csv" {
+			return fmt.Errorf("unsupported format %q: use table, json or csv", format)
+		}
+
+		dimensions, err := parseReportDimensions(groupBy)
+		if err != nil {
+			return err
+		}
+
+		var expr filter.Expr
+		if len(args) > 0 {
+			expr, err = filter.Parse(args[0], transferFields)
+			if err != nil {
+				return fmt.Errorf("invalid filter: %w", err)
+			}
+		}
+
+		profileID, err = resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		since, until, err := transferRange(cmd)
+		if err != nil {
+			return err
+		}
+
+		recipientNames := make(map[int]string)
+		recipients, err := listRecipients(profileID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch recipients: %v\n", err)
+		}
+		for _, r := range recipients {
+			recipientNames[r.ID] = r.Name.FullName
+		}
+
+		fees := newTransferFees(profileID, quoteFees)
+		groups := make(map[string]*reportGroup)
+		total := 0
+		err = eachTransfer(queries.ListTransfersRequest{
+			ProfileID: profileID,
+			Since:     &since,
+			Until:     &until,
+		}, func(t queries.Transfer) error {
+			if !all && unbookedStatuses[t.Status] {
+				return nil
+			}
+
+			row := transferRow{transfer: t, recipientName: recipientNames[t.TargetAccount]}
+			if expr != nil && !expr.Match(row.record()) {
+				return nil
+			}
+
+			keys := make([]string, len(dimensions))
+			for i, dimension := range dimensions {
+				keys[i] = reportValue(row, dimension)
+			}
+			id := strings.Join(append(keys, t.SourceCurrency, t.TargetCurrency), "\x00")
+
+			g, ok := groups[id]
+			if !ok {
+				g = &reportGroup{keys: keys, sourceCurrency: t.SourceCurrency, targetCurrency: t.TargetCurrency}
+				groups[id] = g
+			}
+			g.count++
+			g.source += t.SourceValue
+			g.target += t.TargetValue
+			g.fees += fees.fee(t)
+			g.weightedRate += t.Rate * t.SourceValue
+			total++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list transfers: %w", err)
+		}
+		fees.save()
+
+		sorted := make([]*reportGroup, 0, len(groups))
+		for _, g := range groups {
+			sorted = append(sorted, g)
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			a, b := sorted[i], sorted[j]
+			for k := range a.keys {
+				if a.keys[k] != b.keys[k] {
+					return strings.ToLower(a.keys[k]) < strings.ToLower(b.keys[k])
+				}
+			}
+			if a.sourceCurrency != b.sourceCurrency {
+				return a.sourceCurrency < b.sourceCurrency
+			}
+			return a.targetCurrency < b.targetCurrency
+		})
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		switch format {
+		case "json":
+			err = writeReportJSON(w, dimensions, sorted)
+		case "csv":
+			err = writeReportCSV(w, dimensions, sorted)
+		default:
+			writeReportTable(w, dimensions, sorted, total)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+
+		if output != "" {
+			fmt.Printf("✓ Wrote %d groups covering %d transfers to %s\n", len(sorted), total, output)
+		}
+		return nil
+	},
+}
+
+// parseReportDimensions resolves a comma-separated --group-by list
+func parseReportDimensions(spec string) ([]string, error) {
+	var dimensions []string
+	for _, dimension := range strings.Split(spec, ",") {
+		dimension = strings.ToLower(strings.TrimSpace(dimension))
+		if dimension == "" {
+			continue
+		}
+		if _, ok := reportDimensions[dimension]; !ok {
+			return nil, fmt.Errorf("cannot group by %q: use recipient, currency, month or status", dimension)
+		}
+		dimensions = append(dimensions, dimension)
+	}
+	if len(dimensions) == 0 {
+		return nil, fmt.Errorf("--group-by needs at least one of recipient, currency, month or status")
+	}
+	return dimensions, nil
+}
+
+// reportValue returns the value a transfer is grouped under for one dimension
+func reportValue(row transferRow, dimension string) string {
+	t := row.transfer
+	switch dimension {
+	case "recipient":
+		if row.recipientName != "" {
+			return row.recipientName
+		}
+		return fmt.Sprintf("Recipient %d", t.TargetAccount)
+	case "currency":
+		return t.TargetCurrency
+	case "month":
+		if date := dateOf(t.Created); len(date) >= 7 {
+			return date[:7]
+		}
+		return t.Created
+	case "status":
+		return t.Status
+	}
+	return ""
+}
+
+// writeReportTable prints the groups as a table followed by a summary line
+func writeReportTable(w io.Writer, dimensions []string, groups []*reportGroup, total int) {
+	if len(groups) == 0 {
+		fmt.Fprintln(w, "No transfers found")
+		return
+	}
+
+	width := 0
+	for _, dimension := range dimensions {
+		fmt.Fprintf(w, "%-*s ", reportDimensions[dimension], strings.ToUpper(dimension[:1])+dimension[1:])
+		width += reportDimensions[dimension] + 1
+	}
+	fmt.Fprintf(w, "%-6s %-18s %-18s %-14s %-10s\n", "Count", "Source", "Target", "Fees", "Avg Rate")
+	fmt.Fprintln(w, strings.Repeat("-", width+70))
+
+	for _, g := range groups {
+		for i, dimension := range dimensions {
+			fmt.Fprintf(w, "%-*s ", reportDimensions[dimension], g.keys[i])
+		}
+		fmt.Fprintf(w, "%-6d %-18s %-18s %-14s %-10.4f\n",
+			g.count,
+			fmt.Sprintf("%.2f %s", g.source, g.sourceCurrency),
+			fmt.Sprintf("%.2f %s", g.target, g.targetCurrency),
+			fmt.Sprintf("%.2f %s", g.fees, g.sourceCurrency),
+			g.averageRate(),
+		)
+	}
+
+	fmt.Fprintf(w, "\n%d transfers in %d groups\n", total, len(groups))
+}
+
+// writeReportJSON writes the groups as a JSON array
+func writeReportJSON(w io.Writer, dimensions []string, groups []*reportGroup) error {
+	entries := make([]reportEntry, 0, len(groups))
+	for _, g := range groups {
+		group := make(map[string]string, len(dimensions))
+		for i, dimension := range dimensions {
+			group[dimension] = g.keys[i]
+		}
+		entries = append(entries, reportEntry{
+			Group:       group,
+			Count:       g.count,
+			Source:      queries.Money{Value: round2(g.source), Currency: g.sourceCurrency},
+			Target:      queries.Money{Value: round2(g.target), Currency: g.targetCurrency},
+			Fees:        queries.Money{Value: round2(g.fees), Currency: g.sourceCurrency},
+			AverageRate: g.averageRate(),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
+// writeReportCSV writes the groups with one column per dimension
+func writeReportCSV(w io.Writer, dimensions []string, groups []*reportGroup) error {
+	writer := csv.NewWriter(w)
+
+	header := append(append([]string{}, dimensions...),
+		"count", "source_amount", "source_currency", "target_amount", "target_currency", "fees", "average_rate")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		record := append(append([]string{}, g.keys...),
+			strconv.Itoa(g.count),
+			strconv.FormatFloat(g.source, 'f', 2, 64),
+			g.sourceCurrency,
+			strconv.FormatFloat(g.target, 'f', 2, 64),
+			g.targetCurrency,
+			strconv.FormatFloat(g.fees, 'f', 2, 64),
+			strconv.FormatFloat(g.averageRate(), 'f', 6, 64),
+		)
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// round2 rounds an amount to cents for JSON output
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func init() {
+	reportCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+	reportCmd.Flags().IntP("days", "d", 30, "Number of days to look back")
+	reportCmd.Flags().String("since", "", "Only transfers created on or after this date (YYYY-MM-DD or RFC 3339), overrides --days")
+	reportCmd.Flags().String("until", "", "Only transfers created on or before this date (YYYY-MM-DD or RFC 3339, default: now)")
+	reportCmd.Flags().StringP("group-by", "g", "recipient", "Comma-separated grouping: recipient, currency, month, status")
+	reportCmd.Flags().StringP("format", "f", "table", "Output format: table, json or csv")
+	reportCmd.Flags().StringP("output", "o", "", "Write to a file instead of stdout (optional)")
+	reportCmd.Flags().Bool("all", false, "Include cancelled, refunded and bounced transfers")
+	reportCmd.Flags().Bool("quote-fees", false, "Fetch the quote of transfers without a recorded fee for the exact fee, instead of deriving it from the rate")
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dhamidi/wise-cli/queries"
+)
+
+// maxRequirementRefreshes bounds how often requirements are re-fetched while collecting values
+const maxRequirementRefreshes = 10
+
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// stdinIsTerminal reports whether values can be prompted for interactively
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// parseKeyValues parses repeated key=value flag arguments
+func parseKeyValues(pairs []string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid key=value pair: %q", pair)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// nestValues turns dotted keys such as "address.city" into nested maps
+func nestValues(values map[string]string) map[string]interface{} {
+	nested := make(map[string]interface{})
+	for key, value := range values {
+		if value == "" {
+			continue
+		}
+		parts := strings.Split(key, ".")
+		current := nested
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := current[part].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				current[part] = child
+			}
+			current = child
+		}
+		current[parts[len(parts)-1]] = value
+	}
+	return nested
+}
+
+// findRequirement returns the requirement of the given type
+func findRequirement(requirements []queries.Requirement, reqType string) (*queries.Requirement, error) {
+	types := make([]string, 0, len(requirements))
+	for i := range requirements {
+		if requirements[i].Type == reqType {
+			return &requirements[i], nil
+		}
+		types = append(types, requirements[i].Type)
+	}
+	sort.Strings(types)
+	return nil, fmt.Errorf("type %q is not available, choose one of: %s", reqType, strings.Join(types, ", "))
+}
+
+// collectRequirements fills values for every field of the requirement with the
+// given type, prompting for missing values when running interactively. Optional
+// fields are only prompted for with promptOptional set. Whenever a field asks
+// for it, refresh is called with the values gathered so far and collection
+// continues against the updated requirements.
+func collectRequirements(requirements []queries.Requirement, reqType string, values map[string]string, promptOptional bool, refresh func(map[string]string) ([]queries.Requirement, error)) error {
+	interactive := stdinIsTerminal()
+	refreshed := make(map[string]string)
+
+	for attempt := 0; attempt <= maxRequirementRefreshes; attempt++ {
+		requirement, err := findRequirement(requirements, reqType)
+		if err != nil {
+			return err
+		}
+
+		needsRefresh := false
+	fields:
+		for _, group := range requirement.Fields {
+			for i := range group.Group {
+				field := &group.Group[i]
+
+				value, provided := values[field.Key]
+				if !provided && !field.Required && (!interactive || !promptOptional) {
+					continue
+				}
+				for {
+					if !provided && interactive {
+						value, err = promptRequirementField(group.Name, field)
+						if err != nil {
+							return err
+						}
+					}
+					if err := field.Validate(value); err != nil {
+						if interactive {
+							fmt.Fprintf(os.Stderr, "  %v\n", err)
+							provided = false
+							continue
+						}
+						return fmt.Errorf("invalid field: %w", err)
+					}
+					break
+				}
+				values[field.Key] = value
+
+				if field.RefreshRequirementsOnChange && refreshed[field.Key] != value {
+					refreshed[field.Key] = value
+					needsRefresh = true
+					break fields
+				}
+			}
+		}
+
+		if !needsRefresh {
+			return nil
+		}
+
+		requirements, err = refresh(values)
+		if err != nil {
+			return fmt.Errorf("failed to refresh requirements: %w", err)
+		}
+	}
+
+	return fmt.Errorf("requirements kept changing after %d refreshes", maxRequirementRefreshes)
+}
+
+// promptRequirementField asks for the value of a single field on stdin
+func promptRequirementField(groupName string, field *queries.RequirementField) (string, error) {
+	label := field.Name
+	if label == "" {
+		label = groupName
+	}
+	if !field.Required {
+		label += " (optional)"
+	}
+
+	if len(field.ValuesAllowed) > 0 {
+		fmt.Printf("%s:\n", label)
+		for i, allowed := range field.ValuesAllowed {
+			fmt.Printf("  [%d] %s (%s)\n", i+1, allowed.Name, allowed.Key)
+		}
+		fmt.Print("Choice: ")
+	} else if field.Example != "" {
+		fmt.Printf("%s [e.g. %s]: ", label, field.Example)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read %s from stdin", field.Key)
+	}
+	value := strings.TrimSpace(line)
+
+	// Allow choosing a permitted value by its number
+	if len(field.ValuesAllowed) > 0 {
+		if n, err := strconv.Atoi(value); err == nil && n >= 1 && n <= len(field.ValuesAllowed) {
+			value = field.ValuesAllowed[n-1].Key
+		}
+	}
+
+	return value, nil
+}
+
+// isBuiltinRecipientType reports whether new recipient has dedicated flags for a type
+func isBuiltinRecipientType(recipientType string) bool {
+	switch recipientType {
+	case "sort_code", "iban", "us", "email":
+		return true
+	}
+	return false
+}
+
+// collectAccountDetails gathers recipient details for any account type using
+// the account requirements endpoint, from --field values and interactive prompts
+func collectAccountDetails(profileID int, sourceCurrency, targetCurrency string, sourceAmount float64, recipientType, accountHolderName string, fieldPairs []string) (map[string]interface{}, error) {
+	values, err := parseKeyValues(fieldPairs)
+	if err != nil {
+		return nil, err
+	}
+	values["accountHolderName"] = accountHolderName
+
+	reqParams := queries.AccountRequirementsRequest{
+		SourceCurrency: sourceCurrency,
+		TargetCurrency: targetCurrency,
+		SourceAmount:   sourceAmount,
+	}
+
+	fmt.Printf("Fetching account requirements: %s → %s\n", sourceCurrency, targetCurrency)
+	requirements, err := queries.GetAccountRequirements(apiToken, reqParams, refresh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account requirements: %w", err)
+	}
+
+	accountPayload := func(values map[string]string) map[string]interface{} {
+		details := make(map[string]string, len(values))
+		for key, value := range values {
+			if key != "accountHolderName" {
+				details[key] = value
+			}
+		}
+		return map[string]interface{}{
+			"type":              recipientType,
+			"profile":           profileID,
+			"currency":          targetCurrency,
+			"accountHolderName": accountHolderName,
+			"details":           nestValues(details),
+		}
+	}
+
+	err = collectRequirements(requirements, recipientType, values, true, func(values map[string]string) ([]queries.Requirement, error) {
+		return queries.RefreshAccountRequirements(apiToken, reqParams, accountPayload(values))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accountPayload(values)["details"].(map[string]interface{}), nil
+}
+
+// collectTransferDetails checks the transfer requirements of a quote and
+// recipient, such as the purpose of payment or source of funds, and gathers
+// the required details from --detail values and interactive prompts. The
+// reference is sent to Wise with the other details but not returned.
+func collectTransferDetails(quoteID string, targetAccount int, reference string, details map[string]string) (map[string]interface{}, error) {
+	values := make(map[string]string, len(details)+1)
+	for key, value := range details {
+		values[key] = value
+	}
+	if reference != "" {
+		values["reference"] = reference
+	}
+
+	fetch := func(values map[string]string) ([]queries.Requirement, error) {
+		return queries.GetTransferRequirements(apiToken, queries.TransferRequirementsRequest{
+			TargetAccount: targetAccount,
+			QuoteUUID:     quoteID,
+			Details:       nestValues(values),
+		})
+	}
+
+	requirements, err := fetch(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer requirements: %w", err)
+	}
+
+	// Corridors without extra requirements take the details as given
+	if _, err := findRequirement(requirements, "transfer"); err == nil {
+		if err := collectRequirements(requirements, "transfer", values, false, fetch); err != nil {
+			return nil, err
+		}
+	}
+
+	delete(values, "reference")
+	return nestValues(values), nil
+}
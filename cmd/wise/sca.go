@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/sca"
+	"github.com/spf13/cobra"
+)
+
+var scaCmd = &cobra.Command{
+	Use:   "sca",
+	Short: "Manage the strong customer authentication signing key",
+	Long:  "Some endpoints, such as balance statements, require one-time tokens to be signed with a key whose public half is registered with Wise",
+}
+
+var scaKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate a signing key",
+	Long:  "Generate an RSA key pair, store the private key in the cache directory and print the public key to upload in Wise under Settings > API tokens > Manage public keys",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+
+		keyPath, err := config.PrivateKeyPath()
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(keyPath); err == nil && !force {
+			return fmt.Errorf("signing key already exists at %s: use --force to replace it", keyPath)
+		}
+
+		privatePEM, publicPEM, err := sca.GenerateKey()
+		if err != nil {
+			return err
+		}
+		if err := config.SavePrivateKey(privatePEM); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Saved private key to %s\n\n", keyPath)
+		fmt.Println("Upload this public key to Wise:")
+		fmt.Print(string(publicPEM))
+		return nil
+	},
+}
+
+func init() {
+	scaCmd.AddCommand(scaKeygenCmd)
+
+	scaKeygenCmd.Flags().Bool("force", false, "Replace an existing signing key")
+}
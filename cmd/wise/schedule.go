@@ -0,0 +1,423 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/cron"
+	"github.com/dhamidi/wise-cli/paymentref"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// maxScheduleRuns is how many past outcomes are kept per schedule
+const maxScheduleRuns = 50
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage scheduled payments",
+	Long:  "Manage recurring payments that are executed by 'schedule run'",
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add <recipient-name-or-alias> <amount> [currency]",
+	Short: "Add a scheduled payment",
+	Long:  "Add a recurring payment to the local schedule store. Currency, reference and source account default to the alias settings when an alias is used.",
+	Args:  cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		recipientName := args[0]
+		amount := 0.0
+		if _, err := fmt.Sscanf(args[1], "%f", &amount); err != nil {
+			return fmt.Errorf("invalid amount: %w", err)
+		}
+		if amount <= 0 {
+			return fmt.Errorf("amount is required and must be greater than 0")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		reference, _ := cmd.Flags().GetString("reference")
+		sourceAccount, _ := cmd.Flags().GetInt("source-account")
+		cronExpr, _ := cmd.Flags().GetString("cron")
+		policy, _ := cmd.Flags().GetString("policy")
+		detailPairs, _ := cmd.Flags().GetStringArray("detail")
+
+		cronSchedule, err := cron.Parse(cronExpr)
+		if err != nil {
+			return err
+		}
+		if err := paymentref.Check(reference); err != nil {
+			return fmt.Errorf("invalid reference: %w", err)
+		}
+		if policy != config.PolicyCatchUp && policy != config.PolicySkip {
+			return fmt.Errorf("invalid policy %q: must be %s or %s", policy, config.PolicyCatchUp, config.PolicySkip)
+		}
+		details, err := parseKeyValues(detailPairs)
+		if err != nil {
+			return err
+		}
+
+		profileID, err = resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		alias, err := config.LookupAlias(recipientName)
+		if err != nil {
+			return fmt.Errorf("failed to load aliases: %w", err)
+		}
+
+		currency := ""
+		if len(args) == 3 {
+			currency = args[2]
+		} else if alias != nil {
+			currency = alias.Currency
+		}
+		if currency == "" {
+			return fmt.Errorf("currency is required")
+		}
+		if alias != nil {
+			if reference == "" {
+				reference = alias.Reference
+			}
+			if sourceAccount == 0 {
+				sourceAccount = alias.SourceAccount
+			}
+		}
+
+		// Make sure the recipient resolves now rather than at the first run
+		recipient, err := resolveRecipient(profileID, alias, recipientName, currency)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		schedule := config.Schedule{
+			ID:            uuid.New().String()[:8],
+			Recipient:     recipientName,
+			Amount:        amount,
+			Currency:      currency,
+			Reference:     reference,
+			SourceAccount: sourceAccount,
+			Details:       details,
+			ProfileID:     profileID,
+			Cron:          cronExpr,
+			Policy:        policy,
+			CreatedAt:     now,
+			LastRun:       now,
+		}
+
+		// A concurrent 'schedule run' rewrites schedules.json, so hold its lock
+		release, err := config.AcquireLock("schedule")
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		schedules, err := config.LoadSchedules()
+		if err != nil {
+			return err
+		}
+		schedules = append(schedules, schedule)
+		if err := config.SaveSchedules(schedules); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Scheduled %.2f %s to %s (ID: %s)\n", amount, currency, recipient.Name.FullName, schedule.ID)
+		fmt.Printf("Next run: %s\n", cronSchedule.Next(now).Format("2006-01-02 15:04"))
+		return nil
+	},
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled payments",
+	Long:  "List all scheduled payments with their next run and last outcome",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schedules, err := config.LoadSchedules()
+		if err != nil {
+			return err
+		}
+
+		if len(schedules) == 0 {
+			fmt.Println("No schedules found")
+			return nil
+		}
+
+		// Format output
+		fmt.Printf("%-10s %-20s %-15s %-15s %-10s %-18s %-10s\n", "ID", "Recipient", "Amount", "Cron", "Policy", "Next Run", "Last")
+		fmt.Println(strings.Repeat("-", 105))
+
+		now := time.Now()
+		for _, s := range schedules {
+			nextRun := "-"
+			if cronSchedule, err := cron.Parse(s.Cron); err == nil {
+				if next := cronSchedule.Next(now); !next.IsZero() {
+					nextRun = next.Format("2006-01-02 15:04")
+				}
+			}
+
+			lastStatus := "-"
+			if len(s.Runs) > 0 {
+				lastStatus = s.Runs[len(s.Runs)-1].Status
+			}
+
+			fmt.Printf("%-10s %-20s %-15s %-15s %-10s %-18s %-10s\n",
+				s.ID,
+				s.Recipient,
+				fmt.Sprintf("%.2f %s", s.Amount, s.Currency),
+				s.Cron,
+				s.Policy,
+				nextRun,
+				lastStatus,
+			)
+		}
+
+		return nil
+	},
+}
+
+var scheduleRmCmd = &cobra.Command{
+	Use:   "rm <schedule-id>",
+	Short: "Remove a scheduled payment",
+	Long:  "Remove a scheduled payment from the local schedule store",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		release, err := config.AcquireLock("schedule")
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		schedules, err := config.LoadSchedules()
+		if err != nil {
+			return err
+		}
+
+		remaining := schedules[:0]
+		found := false
+		for _, s := range schedules {
+			if s.ID == args[0] {
+				found = true
+				continue
+			}
+			remaining = append(remaining, s)
+		}
+		if !found {
+			return fmt.Errorf("schedule not found: %s", args[0])
+		}
+
+		if err := config.SaveSchedules(remaining); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Removed schedule %s\n", args[0])
+		return nil
+	},
+}
+
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Execute due scheduled payments",
+	Long:  "Execute every scheduled payment that is due. Safe to call repeatedly from cron or systemd timers: each occurrence uses a deterministic customer transaction ID and is executed at most once.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		maxCatchUp, _ := cmd.Flags().GetInt("max-catch-up")
+
+		release, err := config.AcquireLock("schedule")
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		schedules, err := config.LoadSchedules()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		executed, failed := 0, 0
+
+		for i := range schedules {
+			s := &schedules[i]
+
+			cronSchedule, err := cron.Parse(s.Cron)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Schedule %s: %v\n", s.ID, err)
+				failed++
+				continue
+			}
+
+			due := cronSchedule.Between(s.LastRun, now)
+			if len(due) == 0 {
+				continue
+			}
+
+			// Apply the missed-run policy
+			skipped, due := s.SplitDue(due, maxCatchUp)
+			for _, occurrence := range skipped {
+				fmt.Printf("Schedule %s: skipping missed run %s\n", s.ID, occurrence.Format("2006-01-02 15:04"))
+				if !dryRun {
+					recordScheduleRun(s, config.ScheduleRun{
+						Occurrence: occurrence,
+						RanAt:      now,
+						Status:     "skipped",
+					})
+					s.LastRun = occurrence
+				}
+			}
+
+			for _, occurrence := range due {
+				customerTxID := scheduleTransactionID(s.ID, occurrence)
+
+				if dryRun {
+					fmt.Printf("Schedule %s: would send %.2f %s to %s for %s (customer transaction ID %s)\n",
+						s.ID, s.Amount, s.Currency, s.Recipient, occurrence.Format("2006-01-02 15:04"), customerTxID)
+					continue
+				}
+
+				fmt.Printf("Schedule %s: sending %.2f %s to %s for %s\n",
+					s.ID, s.Amount, s.Currency, s.Recipient, occurrence.Format("2006-01-02 15:04"))
+				run := runScheduledPayment(s, occurrence, customerTxID)
+				run.RanAt = time.Now()
+				recordScheduleRun(s, run)
+
+				if run.Status == "failed" {
+					// Leave LastRun untouched so the occurrence is retried with the same ID next time
+					fmt.Fprintf(os.Stderr, "Schedule %s: %s\n", s.ID, run.Error)
+					failed++
+				} else {
+					fmt.Printf("Schedule %s: ✓ transfer %d\n", s.ID, run.TransferID)
+					s.LastRun = occurrence
+					executed++
+				}
+
+				// Persist progress after every occurrence so a crash never repeats finished work
+				if err := config.SaveSchedules(schedules); err != nil {
+					return err
+				}
+
+				if run.Status == "failed" {
+					break
+				}
+			}
+
+			if !dryRun {
+				if err := config.SaveSchedules(schedules); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !dryRun {
+			fmt.Printf("%d executed, %d failed\n", executed, failed)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d scheduled payment(s) failed", failed)
+		}
+
+		return nil
+	},
+}
+
+// runScheduledPayment executes one occurrence of a schedule, reusing an earlier
+// transfer with the same customer transaction ID if one was already recorded
+func runScheduledPayment(s *config.Schedule, occurrence time.Time, customerTxID string) config.ScheduleRun {
+	run := config.ScheduleRun{
+		Occurrence:            occurrence,
+		CustomerTransactionID: customerTxID,
+	}
+
+	if existing, err := config.LoadTransfer(customerTxID); err == nil {
+		run.Status = "executed"
+		run.TransferID = existing.ID
+		return run
+	}
+
+	alias, err := config.LookupAlias(s.Recipient)
+	if err != nil {
+		run.Status = "failed"
+		run.Error = fmt.Sprintf("failed to load aliases: %v", err)
+		return run
+	}
+
+	recipient, err := resolveRecipient(s.ProfileID, alias, s.Recipient, s.Currency)
+	if err != nil {
+		run.Status = "failed"
+		run.Error = err.Error()
+		return run
+	}
+
+	// References are rendered for the occurrence time so {{month}} names the month being paid
+	seqKey := "schedule-" + s.ID
+	reference, err := renderReference(s.Reference, recipient, "", seqKey, occurrence, false)
+	if err != nil {
+		run.Status = "failed"
+		run.Error = fmt.Sprintf("invalid reference: %v", err)
+		return run
+	}
+
+	transfer, err := executeSend(sendRequest{
+		ProfileID:        s.ProfileID,
+		Recipient:        recipient,
+		Amount:           s.Amount,
+		Currency:         s.Currency,
+		Reference:        reference,
+		SourceAccount:    s.SourceAccount,
+		CustomerTxID:     customerTxID,
+		Details:          s.Details,
+		ReserveReference: reserveReference(s.Reference, recipient, "", seqKey, occurrence),
+	})
+	if err != nil {
+		run.Status = "failed"
+		run.Error = err.Error()
+		return run
+	}
+
+	run.Status = "executed"
+	run.TransferID = transfer.ID
+	return run
+}
+
+// recordScheduleRun appends an outcome to the schedule, keeping only the most recent runs
+func recordScheduleRun(s *config.Schedule, run config.ScheduleRun) {
+	s.Runs = append(s.Runs, run)
+	if len(s.Runs) > maxScheduleRuns {
+		s.Runs = s.Runs[len(s.Runs)-maxScheduleRuns:]
+	}
+}
+
+// scheduleTransactionID derives a stable customer transaction ID for one
+// occurrence of a schedule, so that retries are deduplicated by Wise
+func scheduleTransactionID(scheduleID string, occurrence time.Time) string {
+	name := fmt.Sprintf("wise-cli/schedule/%s/%s", scheduleID, occurrence.UTC().Format(time.RFC3339))
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
+
+func init() {
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleRmCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+
+	scheduleAddCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+	scheduleAddCmd.Flags().StringP("reference", "r", "", "Payment reference template, rendered for each run, e.g. \"Rent {{month}}\" (optional)")
+	scheduleAddCmd.Flags().IntP("source-account", "s", 0, "Source account ID (optional)")
+	scheduleAddCmd.Flags().String("cron", "", "Cron expression: minute hour day-of-month month day-of-week (required)")
+	scheduleAddCmd.MarkFlagRequired("cron")
+	scheduleAddCmd.Flags().StringArray("detail", nil, transferDetailUsage)
+	scheduleAddCmd.Flags().String("policy", config.PolicyCatchUp, "Missed run policy: catch-up or skip")
+
+	scheduleRunCmd.Flags().BoolP("dry-run", "n", false, "Show due payments without executing them")
+	scheduleRunCmd.Flags().Int("max-catch-up", 12, "Maximum number of missed runs to execute per schedule with the catch-up policy (0 for no limit)")
+}
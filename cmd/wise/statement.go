@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/ofx"
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/dhamidi/wise-cli/sca"
+	"github.com/spf13/cobra"
+)
+
+// statementFormats maps each output format to the statement type requested
+// from Wise and the file extension written
+var statementFormats = map[string]struct {
+	extension string
+	fileExt   string
+}{
+	"csv":     {"csv", "csv"},
+	"json":    {"json", "json"},
+	"pdf":     {"pdf", "pdf"},
+	"camt053": {"xml", "camt053.xml"},
+	"ofx":     {"json", "ofx"}, // rendered locally from the JSON statement
+}
+
+var statementCmd = &cobra.Command{
+	Use:   "statement",
+	Short: "Download a balance statement",
+	Long: `Download the statement of a currency balance for a date range as CSV, JSON, PDF, CAMT.053 or OFX.
+Files are named wise-statement-<profile>-<currency>-<from>_<to>.<ext> so a monthly job can archive them.
+Without --from and --to the previous calendar month is used.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		currency, _ := cmd.Flags().GetString("currency")
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+		format, _ := cmd.Flags().GetString("format")
+		statementType, _ := cmd.Flags().GetString("type")
+		dir, _ := cmd.Flags().GetString("dir")
+		output, _ := cmd.Flags().GetString("output")
+
+		currency = strings.ToUpper(currency)
+		statementType = strings.ToUpper(statementType)
+		if statementType != "COMPACT" && statementType != "FLAT" {
+			return fmt.Errorf("invalid --type %q: use COMPACT or FLAT", statementType)
+		}
+
+		spec, ok := statementFormats[format]
+		if !ok {
+			return fmt.Errorf("unsupported format %q: use csv, json, pdf, camt053 or ofx", format)
+		}
+
+		from, to, err := statementPeriod(fromStr, toStr, time.Now())
+		if err != nil {
+			return err
+		}
+
+		profileID, err = resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		balances, err := queries.ListBalancesWithRefresh(apiToken, queries.ListBalancesRequest{
+			ProfileID: profileID,
+		}, refresh)
+		if err != nil {
+			return fmt.Errorf("failed to list balances: %w", err)
+		}
+		balance, err := queries.FindBalance(balances, currency)
+		if err != nil {
+			return err
+		}
+
+		signer, err := signerFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		// The interval end is inclusive, down to the last millisecond of the day
+		req := queries.StatementRequest{
+			ProfileID:     profileID,
+			BalanceID:     balance.ID,
+			Currency:      currency,
+			IntervalStart: from,
+			IntervalEnd:   to.AddDate(0, 0, 1).Add(-time.Millisecond),
+			Type:          statementType,
+		}
+
+		body, err := queries.GetStatement(apiToken, req, spec.extension, signer)
+		if err != nil {
+			return fmt.Errorf("failed to get statement: %w", err)
+		}
+
+		switch format {
+		case "json":
+			var indented bytes.Buffer
+			if err := json.Indent(&indented, body, "", "  "); err != nil {
+				return fmt.Errorf("failed to parse statement: %w", err)
+			}
+			indented.WriteByte('\n')
+			body = indented.Bytes()
+		case "ofx":
+			statement, err := queries.ParseStatement(body)
+			if err != nil {
+				return err
+			}
+			var rendered bytes.Buffer
+			if err := ofx.WriteStatement(&rendered, statement, strconv.Itoa(balance.ID), req.IntervalStart, req.IntervalEnd); err != nil {
+				return fmt.Errorf("failed to render OFX: %w", err)
+			}
+			body = rendered.Bytes()
+		}
+
+		if output == "-" {
+			_, err := os.Stdout.Write(body)
+			return err
+		}
+		if output == "" {
+			output = filepath.Join(dir, statementFileName(profileID, currency, from, to, spec.fileExt))
+		}
+
+		if err := os.WriteFile(output, body, 0644); err != nil {
+			return fmt.Errorf("failed to write statement: %w", err)
+		}
+
+		fmt.Printf("✓ Saved %s statement %s to %s to %s\n", currency, from.Format("2006-01-02"), to.Format("2006-01-02"), output)
+		return nil
+	},
+}
+
+// statementPeriod parses the --from and --to dates, defaulting to the
+// calendar month before now
+func statementPeriod(fromStr, toStr string, now time.Time) (time.Time, time.Time, error) {
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	from := firstOfMonth.AddDate(0, -1, 0)
+	to := firstOfMonth.AddDate(0, 0, -1)
+
+	var err error
+	if fromStr != "" {
+		if from, err = time.Parse("2006-01-02", fromStr); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --from date (expected YYYY-MM-DD): %w", err)
+		}
+	}
+	if toStr != "" {
+		if to, err = time.Parse("2006-01-02", toStr); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --to date (expected YYYY-MM-DD): %w", err)
+		}
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--to %s is before --from %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+
+	return from, to, nil
+}
+
+// statementFileName builds the deterministic file name of a statement
+func statementFileName(profileID int, currency string, from, to time.Time, ext string) string {
+	return fmt.Sprintf("wise-statement-%d-%s-%s_%s.%s", profileID, currency, from.Format("2006-01-02"), to.Format("2006-01-02"), ext)
+}
+
+// privateKeyUsage describes the --private-key flag of commands that may need strong customer authentication
+const privateKeyUsage = "PEM private key for strong customer authentication (or set WISE_PRIVATE_KEY env var, default: key from 'sca keygen')"
+
+// signerFromFlags loads the SCA signing key named by --private-key or WISE_PRIVATE_KEY
+func signerFromFlags(cmd *cobra.Command) (sca.Signer, error) {
+	keyPath, _ := cmd.Flags().GetString("private-key")
+	if keyPath == "" {
+		keyPath = os.Getenv("WISE_PRIVATE_KEY")
+	}
+	return loadSigner(keyPath)
+}
+
+// loadSigner reads the SCA signing key; without a key no challenge can be answered
+func loadSigner(keyPath string) (sca.Signer, error) {
+	pemData, err := config.LoadPrivateKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if pemData == nil {
+		return nil, nil
+	}
+
+	key, err := sca.ParsePrivateKey(pemData)
+	if err != nil {
+		return nil, err
+	}
+
+	return sca.NewSigner(key), nil
+}
+
+func init() {
+	statementCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+	statementCmd.Flags().StringP("currency", "c", "", "Balance currency (required)")
+	statementCmd.Flags().String("from", "", "First day of the statement, YYYY-MM-DD (default: first day of last month)")
+	statementCmd.Flags().String("to", "", "Last day of the statement, YYYY-MM-DD (default: last day of last month)")
+	statementCmd.Flags().StringP("format", "f", "csv", "Output format: csv, json, pdf, camt053 or ofx")
+	statementCmd.Flags().String("type", "COMPACT", "Statement type: COMPACT or FLAT (FLAT lists fees separately)")
+	statementCmd.Flags().String("dir", ".", "Directory to write the statement to")
+	statementCmd.Flags().StringP("output", "o", "", "Write to this path instead, or - for stdout (optional)")
+	statementCmd.Flags().String("private-key", "", privateKeyUsage)
+	statementCmd.MarkFlagRequired("currency")
+}
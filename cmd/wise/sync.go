@@ -0,0 +1,275 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/dhamidi/wise-cli/store"
+	"github.com/spf13/cobra"
+)
+
+// recipientsMaxAge is how long synced recipients are used before they are pulled again
+const recipientsMaxAge = 1 * time.Hour
+
+// errLocalLimit stops reading the store once req.Limit transfers were passed on
+var errLocalLimit = errors.New("transfer limit reached")
+
+// offline makes commands read the local store without calling the API
+var offline bool
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync transfers, recipients and profiles to the local store",
+	Long: `Pull profiles, recipients and transfers into the local store. After the first sync only transfers created
+since the last sync, or still in progress, are fetched. Once a profile is synced, transfers, reports and
+recipients read from the store and only fetch the delta.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		full, _ := cmd.Flags().GetBool("full")
+
+		unlock, err := config.AcquireLock("sync")
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		profiles, err := store.SyncProfiles(apiToken)
+		if err != nil {
+			return err
+		}
+
+		var profileIDs []int
+		if profileID != 0 {
+			profileIDs = []int{profileID}
+		} else {
+			for _, p := range profiles {
+				profileIDs = append(profileIDs, p.ID)
+			}
+		}
+
+		fmt.Printf("✓ %d profiles\n", len(profiles))
+		for _, id := range profileIDs {
+			recipients, err := store.SyncRecipients(apiToken, id)
+			if err != nil {
+				return fmt.Errorf("profile %d: %w", id, err)
+			}
+
+			result, err := store.SyncTransfers(apiToken, id, full)
+			if err != nil {
+				return fmt.Errorf("profile %d: %w", id, err)
+			}
+
+			window := "full history"
+			if result.Since != nil {
+				window = "since " + result.Since.Format("2006-01-02 15:04")
+			}
+			fmt.Printf("✓ Profile %d: %d recipients, %d new and %d updated transfers (%s), %d stored\n",
+				id, recipients, result.NewTransfers, result.UpdatedTransfers, window, result.Transfers)
+		}
+
+		return nil
+	},
+}
+
+// localProfileIDs returns the profiles whose synced data can answer a query
+// for profileID. Without a profile the API answers for all profiles, so the
+// store is only used once every stored profile has been synced.
+func localProfileIDs(profileID int) ([]int, bool) {
+	state, err := store.LoadState()
+	if err != nil {
+		return nil, false
+	}
+
+	if profileID != 0 {
+		if !state.Synced(profileID) {
+			return nil, false
+		}
+		return []int{profileID}, true
+	}
+
+	profiles, err := store.LoadProfiles()
+	if err != nil || len(profiles) == 0 {
+		return nil, false
+	}
+
+	profileIDs := make([]int, 0, len(profiles))
+	for _, p := range profiles {
+		if !state.Synced(p.ID) {
+			return nil, false
+		}
+		profileIDs = append(profileIDs, p.ID)
+	}
+
+	return profileIDs, true
+}
+
+// eachTransfer streams transfers matching req from the local store when the
+// profiles have been synced, fetching only the delta first, and from the API otherwise
+func eachTransfer(req queries.ListTransfersRequest, fn func(queries.Transfer) error) error {
+	profileIDs, local := localProfileIDs(req.ProfileID)
+	if !local {
+		if offline {
+			return fmt.Errorf("profile has not been synced: run 'wise sync' before using --offline")
+		}
+		return queries.EachTransfer(apiToken, req, refresh, fn)
+	}
+
+	if !offline {
+		for _, profileID := range profileIDs {
+			syncDelta(profileID, func() error {
+				_, err := store.SyncTransfers(apiToken, profileID, false)
+				return err
+			})
+		}
+	}
+
+	statuses := make(map[string]bool)
+	for _, status := range strings.Split(req.Status, ",") {
+		if status != "" {
+			statuses[status] = true
+		}
+	}
+
+	skipped, sent := 0, 0
+	err := store.EachTransfer(profileIDs, req.Since, req.Until, func(t queries.Transfer) error {
+		if len(statuses) > 0 && !statuses[t.Status] {
+			return nil
+		}
+		if req.Since != nil || req.Until != nil {
+			created, err := parseTransferTime(t.Created)
+			if err != nil {
+				return nil
+			}
+			if req.Since != nil && created.Before(*req.Since) {
+				return nil
+			}
+			if req.Until != nil && created.After(*req.Until) {
+				return nil
+			}
+		}
+		if skipped < req.Offset {
+			skipped++
+			return nil
+		}
+		if req.Limit > 0 && sent >= req.Limit {
+			return errLocalLimit
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+		sent++
+		return nil
+	})
+	if err == errLocalLimit {
+		return nil
+	}
+	return err
+}
+
+// listRecipients returns recipients from the local store when the profiles have
+// been synced, pulling them again once they are older than recipientsMaxAge,
+// and from the API otherwise. Recipients deleted since they were synced are
+// included so that old transfers still resolve to a name.
+func listRecipients(profileID int) ([]queries.Recipient, error) {
+	profileIDs, local := localProfileIDs(profileID)
+	if !local {
+		if offline {
+			return nil, fmt.Errorf("profile has not been synced: run 'wise sync' before using --offline")
+		}
+		return queries.ListRecipientsWithRefresh(apiToken, queries.ListRecipientsRequest{
+			ProfileID: profileID,
+		}, refresh)
+	}
+
+	if !offline {
+		state, err := store.LoadState()
+		if err != nil {
+			return nil, err
+		}
+		for _, localID := range profileIDs {
+			if refresh || time.Since(state.Profiles[localID].RecipientsSyncedAt) > recipientsMaxAge {
+				syncDelta(localID, func() error {
+					_, err := store.SyncRecipients(apiToken, localID)
+					return err
+				})
+			}
+		}
+	}
+
+	recipients := map[int]queries.Recipient{}
+	for _, localID := range profileIDs {
+		stored, err := store.LoadRecipients(localID)
+		if err != nil {
+			return nil, err
+		}
+		for id, r := range stored {
+			recipients[id] = r
+		}
+	}
+
+	return store.SortedRecipients(recipients), nil
+}
+
+// filterRecipients keeps active recipients matching comma-separated currency
+// and type lists, as the recipients endpoint does
+func filterRecipients(recipients []queries.Recipient, currencies, types string) []queries.Recipient {
+	matches := func(list, value string) bool {
+		if list == "" {
+			return true
+		}
+		for _, item := range strings.Split(list, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var filtered []queries.Recipient
+	for _, r := range recipients {
+		if r.Active && matches(currencies, r.Currency) && matches(types, r.Type) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// invalidateRecipients drops cached recipient lists and marks synced
+// recipients as stale after recipients were created or deleted
+func invalidateRecipients() {
+	if err := config.ClearCacheEntries("recipients-"); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to clear recipient cache: %v\n", err)
+	}
+	if err := store.ExpireRecipients(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to expire synced recipients: %v\n", err)
+	}
+}
+
+// syncDelta runs an incremental sync before a local query. Failures only
+// produce a warning, the query then answers from the data already stored.
+func syncDelta(profileID int, sync func() error) {
+	unlock, err := config.AcquireLock("sync")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping sync: %v\n", err)
+		return
+	}
+	defer unlock()
+
+	if err := sync(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to sync profile %d, showing stored data: %v\n", profileID, err)
+	}
+}
+
+func init() {
+	syncCmd.Flags().IntP("profile-id", "p", 0, "Only sync this profile (default: all profiles)")
+	syncCmd.Flags().Bool("full", false, "Fetch the complete transfer history instead of the delta")
+}
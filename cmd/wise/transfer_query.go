@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dhamidi/wise-cli/filter"
+	"github.com/dhamidi/wise-cli/queries"
+)
+
+// transferFields are the fields available to transfer filters and --sort.
+// amount and currency refer to what the recipient receives.
+var transferFields = map[string]filter.Kind{
+	"id":              filter.Number,
+	"date":            filter.Time,
+	"created":         filter.Time,
+	"status":          filter.String,
+	"recipient":       filter.String,
+	"recipient_id":    filter.Number,
+	"reference":       filter.String,
+	"amount":          filter.Number,
+	"currency":        filter.String,
+	"source_amount":   filter.Number,
+	"source_currency": filter.String,
+	"target_amount":   filter.Number,
+	"target_currency": filter.String,
+	"rate":            filter.Number,
+}
+
+// transferRow is a transfer joined with its recipient's name
+type transferRow struct {
+	transfer      queries.Transfer
+	recipientName string
+}
+
+// reference returns the payment reference of the transfer
+func (r transferRow) reference() string {
+	if r.transfer.Reference != nil && *r.transfer.Reference != "" {
+		return *r.transfer.Reference
+	}
+	return r.transfer.Details.Reference
+}
+
+// record exposes the row's fields to filter expressions and sorting
+func (r transferRow) record() filter.Record {
+	t := r.transfer
+	return func(field string) filter.Value {
+		switch field {
+		case "id":
+			return filter.Value{Num: float64(t.ID)}
+		case "date", "created":
+			created, _ := parseTransferTime(t.Created)
+			return filter.Value{Time: created}
+		case "status":
+			return filter.Value{Str: t.Status}
+		case "recipient":
+			return filter.Value{Str: r.recipientName}
+		case "recipient_id":
+			return filter.Value{Num: float64(t.TargetAccount)}
+		case "reference":
+			return filter.Value{Str: r.reference()}
+		case "amount", "target_amount":
+			return filter.Value{Num: t.TargetValue}
+		case "currency", "target_currency":
+			return filter.Value{Str: t.TargetCurrency}
+		case "source_amount":
+			return filter.Value{Num: t.SourceValue}
+		case "source_currency":
+			return filter.Value{Str: t.SourceCurrency}
+		case "rate":
+			return filter.Value{Num: t.Rate}
+		}
+		return filter.Value{}
+	}
+}
+
+// transferColumn is a column of the transfers table
+type transferColumn struct {
+	name   string
+	header string
+	width  int
+	value  func(r transferRow) string
+}
+
+// transferColumns lists every column --columns can select
+var transferColumns = []transferColumn{
+	{"id", "ID", 10, func(r transferRow) string { return strconv.Itoa(r.transfer.ID) }},
+	{"date", "Date", 20, func(r transferRow) string { return dateOf(r.transfer.Created) }},
+	{"created", "Created", 20, func(r transferRow) string { return r.transfer.Created }},
+	{"source", "Source", 15, func(r transferRow) string {
+		return fmt.Sprintf("%.2f %s", r.transfer.SourceValue, r.transfer.SourceCurrency)
+	}},
+	{"recipient", "Recipient", 30, func(r transferRow) string { return orDash(r.recipientName) }},
+	{"recipient_id", "Recipient ID", 12, func(r transferRow) string { return strconv.Itoa(r.transfer.TargetAccount) }},
+	{"target", "Target", 15, func(r transferRow) string {
+		return fmt.Sprintf("%.2f %s", r.transfer.TargetValue, r.transfer.TargetCurrency)
+	}},
+	{"reference", "Reference", 20, func(r transferRow) string { return orDash(r.reference()) }},
+	{"status", "Status", 10, func(r transferRow) string { return r.transfer.Status }},
+	{"amount", "Amount", 12, func(r transferRow) string { return fmt.Sprintf("%.2f", r.transfer.TargetValue) }},
+	{"currency", "Currency", 8, func(r transferRow) string { return r.transfer.TargetCurrency }},
+	{"source_amount", "Source Amount", 14, func(r transferRow) string { return fmt.Sprintf("%.2f", r.transfer.SourceValue) }},
+	{"source_currency", "Source Cur", 10, func(r transferRow) string { return r.transfer.SourceCurrency }},
+	{"target_amount", "Target Amount", 14, func(r transferRow) string { return fmt.Sprintf("%.2f", r.transfer.TargetValue) }},
+	{"target_currency", "Target Cur", 10, func(r transferRow) string { return r.transfer.TargetCurrency }},
+	{"rate", "Rate", 10, func(r transferRow) string { return fmt.Sprintf("%.4f", r.transfer.Rate) }},
+}
+
+// defaultTransferColumns are shown when --columns is not given
+const defaultTransferColumns = "id,date,source,recipient,target,reference,status"
+
+// selectTransferColumns resolves a comma-separated list of column names
+func selectTransferColumns(spec string) ([]transferColumn, error) {
+	byName := make(map[string]transferColumn, len(transferColumns))
+	names := make([]string, len(transferColumns))
+	for i, c := range transferColumns {
+		byName[c.name] = c
+		names[i] = c.name
+	}
+
+	var columns []transferColumn
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q, available: %s", name, strings.Join(names, ", "))
+		}
+		columns = append(columns, c)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+	return columns, nil
+}
+
+// printTransferHeader prints the table header for the selected columns
+func printTransferHeader(columns []transferColumn) {
+	headers := make([]string, len(columns))
+	width := 0
+	for i, c := range columns {
+		headers[i] = fmt.Sprintf("%-*s", c.width, c.header)
+		width += c.width + 1
+	}
+	fmt.Println(strings.Join(headers, " "))
+	fmt.Println(strings.Repeat("-", width-1))
+}
+
+// printTransferRow prints one transfer in the selected columns
+func printTransferRow(columns []transferColumn, row transferRow) {
+	cells := make([]string, len(columns))
+	for i, c := range columns {
+		cells[i] = fmt.Sprintf("%-*s", c.width, c.value(row))
+	}
+	fmt.Println(strings.Join(cells, " "))
+}
+
+// dateOf returns the YYYY-MM-DD part of a transfer timestamp
+func dateOf(created string) string {
+	if len(created) > 10 {
+		return created[:10]
+	}
+	return created
+}
+
+// orDash substitutes "-" for empty cells
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
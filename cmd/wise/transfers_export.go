@@ -0,0 +1,264 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/accounting"
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/queries"
+	"github.com/dhamidi/wise-cli/store"
+	"github.com/spf13/cobra"
+)
+
+// unbookedStatuses are transfer states where no money left the account
+var unbookedStatuses = map[string]bool{
+	"cancelled":      true,
+	"funds_refunded": true,
+	"bounced_back":   true,
+}
+
+var transfersExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export transfers for accounting",
+	Long: `Export transfers as balanced journal entries for ledger, hledger, beancount, QIF or a Xero bank statement CSV.
+Each transfer debits the source balance, books the fee from the quote's fee breakdown as an expense and credits the
+recipient's account with the payout at the recorded rate. Recipients are routed to accounts with an account mapping
+(accounting.json in the cache directory, or --mapping).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiToken == "" {
+			return fmt.Errorf("API token required: set --token flag or WISE_API_TOKEN env var")
+		}
+
+		profileID, _ := cmd.Flags().GetInt("profile-id")
+		format, _ := cmd.Flags().GetString("format")
+		currency, _ := cmd.Flags().GetString("currency")
+		mappingPath, _ := cmd.Flags().GetString("mapping")
+		output, _ := cmd.Flags().GetString("output")
+
+		if !isAccountingFormat(format) {
+			return fmt.Errorf("unsupported format %q: use %s", format, strings.Join(accounting.Formats, ", "))
+		}
+
+		mapping, err := config.LoadAccountMapping(mappingPath)
+		if err != nil {
+			return err
+		}
+
+		profileID, err = resolveProfileID(profileID)
+		if err != nil {
+			return err
+		}
+
+		since, until, err := transferRange(cmd)
+		if err != nil {
+			return err
+		}
+
+		var transfers []queries.Transfer
+		err = eachTransfer(queries.ListTransfersRequest{
+			ProfileID: profileID,
+			Since:     &since,
+			Until:     &until,
+		}, func(t queries.Transfer) error {
+			transfers = append(transfers, t)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list transfers: %w", err)
+		}
+
+		recipientNames := make(map[int]string)
+		recipients, err := listRecipients(profileID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch recipients: %v\n", err)
+		}
+		for _, r := range recipients {
+			recipientNames[r.ID] = r.Name.FullName
+		}
+
+		aliasNames := make(map[int][]string)
+		aliases, err := config.SortedAliases()
+		if err != nil {
+			return err
+		}
+		for _, alias := range aliases {
+			aliasNames[alias.RecipientID] = append(aliasNames[alias.RecipientID], alias.Name)
+		}
+
+		fees := newTransferFees(profileID, true)
+		var entries []accounting.Entry
+		for _, t := range transfers {
+			if unbookedStatuses[t.Status] {
+				continue
+			}
+			if currency != "" && !strings.EqualFold(t.SourceCurrency, currency) {
+				continue
+			}
+
+			date, err := parseTransferTime(t.Created)
+			if err != nil {
+				return fmt.Errorf("transfer %d: %w", t.ID, err)
+			}
+
+			payee := recipientNames[t.TargetAccount]
+			if payee == "" {
+				payee = fmt.Sprintf("Recipient %d", t.TargetAccount)
+			}
+
+			reference := t.Details.Reference
+			if t.Reference != nil && *t.Reference != "" {
+				reference = *t.Reference
+			}
+
+			entries = append(entries, accounting.TransferEntry(
+				date,
+				t.ID,
+				payee,
+				reference,
+				accounting.Amount{Value: t.SourceValue, Currency: t.SourceCurrency},
+				accounting.Amount{Value: t.TargetValue, Currency: t.TargetCurrency},
+				fees.fee(t),
+				t.Rate,
+				mapping.RecipientAccount(t.TargetAccount, aliasNames[t.TargetAccount], payee),
+				mapping.FeesAccount(t.SourceCurrency),
+				mapping.AssetsAccount(t.SourceCurrency),
+			))
+		}
+
+		fees.save()
+
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Date.Before(entries[j].Date) })
+
+		// QIF and Xero files describe a single bank account
+		if format == "qif" || format == "xero-csv" {
+			if currencies := accounting.SourceCurrencies(entries); len(currencies) > 1 {
+				return fmt.Errorf("transfers are paid from several balances (%s): use --currency to export one at a time", strings.Join(currencies, ", "))
+			}
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if err := accounting.Write(w, format, entries); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+
+		if output != "" {
+			fmt.Printf("✓ Exported %d transfers to %s\n", len(entries), output)
+		}
+		return nil
+	},
+}
+
+// isAccountingFormat reports whether format is a supported accounting export format
+func isAccountingFormat(format string) bool {
+	for _, f := range accounting.Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// transferFees looks up the total fees of transfers. Fees taken from a
+// transfer's quote are recorded in the store, so each quote is fetched once.
+// Without fetch, or when the quote cannot be used, the fee is derived from the
+// difference between the source amount and the target amount at the rate.
+type transferFees struct {
+	profileID int
+	fetch     bool
+	recorded  map[int]float64
+	changed   bool
+	derived   int
+}
+
+// newTransferFees loads the recorded fees of a profile; fetch allows quotes
+// to be fetched for transfers without a recorded fee
+func newTransferFees(profileID int, fetch bool) *transferFees {
+	recorded, err := store.LoadFees(profileID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load recorded fees: %v\n", err)
+		recorded = map[int]float64{}
+	}
+	return &transferFees{profileID: profileID, fetch: fetch && !offline, recorded: recorded}
+}
+
+// fee returns the total fee of a transfer
+func (f *transferFees) fee(t queries.Transfer) float64 {
+	if fee, ok := f.recorded[t.ID]; ok {
+		return fee
+	}
+
+	derived := 0.0
+	if t.Rate > 0 {
+		derived = t.SourceValue - t.TargetValue/t.Rate
+	}
+	if !f.fetch || t.QuoteUUID == "" {
+		return derived
+	}
+
+	quote, err := queries.GetQuoteByID(apiToken, f.profileID, t.QuoteUUID, refresh)
+	if err != nil {
+		f.derived++
+		return derived
+	}
+	fee, ok := quote.FeeFor(t.SourceValue)
+	if !ok {
+		f.derived++
+		return derived
+	}
+
+	f.recorded[t.ID] = fee.Total
+	f.changed = true
+	return fee.Total
+}
+
+// save records the fees taken from quotes and warns once about transfers
+// whose fee had to be derived from the rate
+func (f *transferFees) save() {
+	if f.derived > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: the quotes of %d transfer(s) could not be used, their fees are derived from the rate\n", f.derived)
+	}
+	if !f.changed {
+		return
+	}
+	if err := store.SaveFees(f.profileID, f.recorded); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record fees: %v\n", err)
+	}
+}
+
+// parseTransferTime parses the creation time of a transfer, which the API
+// reports as "2006-01-02 15:04:05" in UTC
+func parseTransferTime(created string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04:05", time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, created); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid creation time %q", created)
+}
+
+func init() {
+	transfersCmd.AddCommand(transfersExportCmd)
+
+	transfersExportCmd.Flags().IntP("profile-id", "p", 0, "Profile ID (optional, uses default if not set)")
+	transfersExportCmd.Flags().IntP("days", "d", 30, "Number of days to look back")
+	transfersExportCmd.Flags().String("since", "", "Only transfers created on or after this date (YYYY-MM-DD or RFC 3339), overrides --days")
+	transfersExportCmd.Flags().String("until", "", "Only transfers created on or before this date (YYYY-MM-DD or RFC 3339, default: now)")
+	transfersExportCmd.Flags().StringP("format", "f", "ledger", "Output format: "+strings.Join(accounting.Formats, ", "))
+	transfersExportCmd.Flags().StringP("currency", "c", "", "Only export transfers paid from this currency (optional)")
+	transfersExportCmd.Flags().String("mapping", "", "Account mapping JSON file (default: accounting.json in the cache directory)")
+	transfersExportCmd.Flags().StringP("output", "o", "", "Write to a file instead of stdout (optional)")
+}
@@ -15,14 +15,25 @@ type NewQuoteRequest struct {
 	TargetCurrency string
 	SourceAmount   *float64
 	TargetAmount   *float64
+	PayIn          string // preferred pay-in method, defaults to BALANCE
+	PayOut         string // optional, BALANCE for conversions between balances
 }
 
 // NewQuote creates an authenticated quote for a currency conversion
 func NewQuote(apiToken string, req NewQuoteRequest) (*Quote, error) {
+	payIn := req.PayIn
+	if payIn == "" {
+		payIn = "BALANCE"
+	}
+
 	payload := map[string]interface{}{
 		"sourceCurrency": req.SourceCurrency,
 		"targetCurrency": req.TargetCurrency,
-		"preferredPayIn": "BALANCE",
+		"preferredPayIn": payIn,
+	}
+
+	if req.PayOut != "" {
+		payload["payOut"] = req.PayOut
 	}
 
 	if req.SourceAmount != nil {
@@ -71,6 +82,63 @@ func NewQuote(apiToken string, req NewQuoteRequest) (*Quote, error) {
 	return &quote, nil
 }
 
+// UpdateQuoteRequest holds parameters for updating an existing quote
+type UpdateQuoteRequest struct {
+	ProfileID     int
+	QuoteID       string
+	TargetAccount int
+	PayOut        string // optional, e.g. SWIFT or BANK_TRANSFER
+}
+
+// UpdateQuote sets the recipient of a quote, which recalculates its fees and
+// payment options for that recipient and extends an expired quote
+func UpdateQuote(apiToken string, req UpdateQuoteRequest) (*Quote, error) {
+	payload := map[string]interface{}{
+		"targetAccount": req.TargetAccount,
+	}
+	if req.PayOut != "" {
+		payload["payOut"] = req.PayOut
+	}
+
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("https://api.wise.com/v3/profiles/%d/quotes/%s", req.ProfileID, req.QuoteID)
+
+	httpReq, err := http.NewRequest("PATCH", endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+	httpReq.Header.Set("Content-Type", "application/merge-patch+json")
+
+	client := &http.Client{}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update quote: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+
+	var quote Quote
+	if err := json.Unmarshal(body, &quote); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &quote, nil
+}
+
 // Quote represents a Wise exchange quote
 type Quote struct {
 	ID                   string                 `json:"id"`
@@ -119,6 +187,18 @@ type DisabledReason struct {
 	Message string `json:"message"`
 }
 
+// PaymentOption returns the option for paying in with payIn and paying out
+// the way the quote does, or nil if the quote has none
+func (q *Quote) PaymentOption(payIn string) *PaymentOption {
+	for i := range q.PaymentOptions {
+		option := &q.PaymentOptions[i]
+		if option.PayIn == payIn && (q.PayOut == "" || option.PayOut == q.PayOut) {
+			return option
+		}
+	}
+	return nil
+}
+
 type Notice struct {
 	Text string  `json:"text"`
 	Link *string `json:"link"`
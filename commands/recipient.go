@@ -99,3 +99,33 @@ func NewRecipient(apiToken string, req NewRecipientRequest) (*Recipient, error)
 
 	return &recipient, nil
 }
+
+// DeleteRecipient deactivates a recipient account using the Wise API
+func DeleteRecipient(apiToken string, recipientID int) error {
+	endpoint := fmt.Sprintf("https://api.wise.com/v1/accounts/%d", recipientID)
+
+	httpReq, err := http.NewRequest("DELETE", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+
+	client := &http.Client{}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to delete recipient: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ type NewTransferRequest struct {
 	CustomerTransactionID string
 	Reference             *string
 	SourceAccount         *int
+	Details               map[string]interface{} // extra details required by the corridor, e.g. transferPurpose
 }
 
 // TransferDetails represents the details field in a transfer
@@ -57,9 +58,13 @@ func NewTransfer(apiToken string, req NewTransferRequest) (*Transfer, error) {
 		payload["sourceAccount"] = *req.SourceAccount
 	}
 
-	if req.Reference != nil {
-		details := map[string]interface{}{
-			"reference": *req.Reference,
+	if req.Reference != nil || len(req.Details) > 0 {
+		details := make(map[string]interface{}, len(req.Details)+1)
+		for key, value := range req.Details {
+			details[key] = value
+		}
+		if req.Reference != nil {
+			details["reference"] = *req.Reference
 		}
 		payload["details"] = details
 	}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const accountingFileName = "accounting.json"
+
+// AccountMapping routes transfers to accounts of a bookkeeping system. The
+// {currency} placeholder in Assets and Fees is replaced by the source currency.
+type AccountMapping struct {
+	Assets     string            `json:"assets,omitempty"`
+	Fees       string            `json:"fees,omitempty"`
+	Default    string            `json:"default,omitempty"`
+	Recipients map[string]string `json:"recipients,omitempty"` // keyed by recipient ID, alias or name
+}
+
+// DefaultAccountMapping returns the accounts used when no mapping is configured
+func DefaultAccountMapping() AccountMapping {
+	return AccountMapping{
+		Assets:     "Assets:Wise:{currency}",
+		Fees:       "Expenses:Fees:Wise",
+		Default:    "Expenses:Transfers",
+		Recipients: map[string]string{},
+	}
+}
+
+// LoadAccountMapping loads the account mapping from path, or accounting.json
+// in the cache directory when path is empty. Unset accounts keep their defaults.
+func LoadAccountMapping(path string) (AccountMapping, error) {
+	mapping := DefaultAccountMapping()
+
+	if path == "" {
+		cacheDir, err := CacheDir()
+		if err != nil {
+			return mapping, err
+		}
+		path = filepath.Join(cacheDir, accountingFileName)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return mapping, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return mapping, fmt.Errorf("failed to read account mapping: %w", err)
+	}
+
+	var loaded AccountMapping
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return mapping, fmt.Errorf("failed to parse account mapping: %w", err)
+	}
+
+	if loaded.Assets != "" {
+		mapping.Assets = loaded.Assets
+	}
+	if loaded.Fees != "" {
+		mapping.Fees = loaded.Fees
+	}
+	if loaded.Default != "" {
+		mapping.Default = loaded.Default
+	}
+	for key, account := range loaded.Recipients {
+		mapping.Recipients[strings.ToLower(key)] = account
+	}
+
+	return mapping, nil
+}
+
+// AssetsAccount returns the account holding the balance in currency
+func (m AccountMapping) AssetsAccount(currency string) string {
+	return strings.ReplaceAll(m.Assets, "{currency}", currency)
+}
+
+// FeesAccount returns the account fees in currency are booked to
+func (m AccountMapping) FeesAccount(currency string) string {
+	return strings.ReplaceAll(m.Fees, "{currency}", currency)
+}
+
+// RecipientAccount returns the account for a recipient, trying its ID, then
+// each alias pointing at it, then its name, and finally the default account
+func (m AccountMapping) RecipientAccount(recipientID int, aliases []string, name string) string {
+	if account, ok := m.Recipients[strconv.Itoa(recipientID)]; ok {
+		return account
+	}
+	for _, alias := range aliases {
+		if account, ok := m.Recipients[strings.ToLower(alias)]; ok {
+			return account
+		}
+	}
+	if account, ok := m.Recipients[strings.ToLower(name)]; ok && name != "" {
+		return account
+	}
+	return m.Default
+}
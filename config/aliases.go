@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+const aliasesFileName = "aliases.json"
+
+// Alias represents a local address book entry pointing at a Wise recipient
+type Alias struct {
+	Name          string `json:"name"`
+	RecipientID   int    `json:"recipientId"`
+	Currency      string `json:"currency,omitempty"`
+	Reference     string `json:"reference,omitempty"`
+	SourceAccount int    `json:"sourceAccount,omitempty"`
+}
+
+// LoadAliases loads all aliases from the address book
+func LoadAliases() (map[string]Alias, error) {
+	aliases := map[string]Alias{}
+	if err := readConfigJSON(aliasesFileName, "aliases", &aliases); err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// SaveAliases writes the complete address book
+func SaveAliases(aliases map[string]Alias) error {
+	return writeConfigJSON(aliasesFileName, "aliases", aliases)
+}
+
+// SetAlias creates or replaces an alias
+func SetAlias(alias Alias) error {
+	aliases, err := LoadAliases()
+	if err != nil {
+		return err
+	}
+
+	aliases[alias.Name] = alias
+	return SaveAliases(aliases)
+}
+
+// RemoveAlias deletes an alias, returning an error if it does not exist
+func RemoveAlias(name string) error {
+	aliases, err := LoadAliases()
+	if err != nil {
+		return err
+	}
+
+	if _, exists := aliases[name]; !exists {
+		return fmt.Errorf("alias not found: %s", name)
+	}
+
+	delete(aliases, name)
+	return SaveAliases(aliases)
+}
+
+// LookupAlias returns the alias with the given name, or nil if none exists
+func LookupAlias(name string) (*Alias, error) {
+	aliases, err := LoadAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	alias, exists := aliases[name]
+	if !exists {
+		return nil, nil
+	}
+
+	return &alias, nil
+}
+
+// SortedAliases returns all aliases ordered by name
+func SortedAliases() ([]Alias, error) {
+	aliases, err := LoadAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]Alias, 0, len(aliases))
+	for _, alias := range aliases {
+		sorted = append(sorted, alias)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	return sorted, nil
+}
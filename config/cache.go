@@ -104,6 +104,27 @@ func SetCacheEntry(cacheKey, data string, headers http.Header) error {
 	return nil
 }
 
+// ClearCacheEntries removes all cached responses whose key starts with prefix
+func ClearCacheEntries(prefix string) error {
+	cacheDir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+
+	matches, err := filepath.Glob(filepath.Join(cacheDir, prefix+"*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list cache: %w", err)
+	}
+
+	for _, path := range matches {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // parseExpiration extracts expiration time from HTTP cache headers
 func parseExpiration(headers http.Header) time.Time {
 	// Try Cache-Control: max-age first
@@ -0,0 +1,50 @@
+package config
+
+import "time"
+
+const conversionsFileName = "conversions.json"
+
+// conversionsLockTimeout is how long recording a conversion waits for another process
+const conversionsLockTimeout = 10 * time.Second
+
+// Conversion records a conversion between two balances made with 'convert'
+type Conversion struct {
+	ID             int       `json:"id"`
+	ProfileID      int       `json:"profileId"`
+	QuoteID        string    `json:"quoteId"`
+	State          string    `json:"state"`
+	SourceAmount   float64   `json:"sourceAmount"`
+	SourceCurrency string    `json:"sourceCurrency"`
+	TargetAmount   float64   `json:"targetAmount"`
+	TargetCurrency string    `json:"targetCurrency"`
+	Rate           float64   `json:"rate"`
+	Fee            float64   `json:"fee"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// LoadConversions loads the conversion history, oldest first
+func LoadConversions() ([]Conversion, error) {
+	var conversions []Conversion
+	if err := readConfigJSON(conversionsFileName, "conversions", &conversions); err != nil {
+		return nil, err
+	}
+	return conversions, nil
+}
+
+// AddConversion appends a conversion to the history. The history is changed
+// under the "conversions" lock so concurrent commands do not drop entries.
+func AddConversion(conversion Conversion) error {
+	release, err := WaitLock("conversions", conversionsLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	conversions, err := LoadConversions()
+	if err != nil {
+		return err
+	}
+
+	conversions = append(conversions, conversion)
+	return writeConfigJSON(conversionsFileName, "conversions", conversions)
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// readConfigJSON decodes a file in the cache directory into v, leaving v untouched if it does not exist
+func readConfigJSON(name, what string, v interface{}) error {
+	cacheDir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filepath.Join(cacheDir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", what, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", what, err)
+	}
+
+	return nil
+}
+
+// writeConfigJSON replaces a file in the cache directory atomically
+func writeConfigJSON(name, what string, v interface{}) error {
+	cacheDir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", what, err)
+	}
+
+	path := filepath.Join(cacheDir, name)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// staleLockAge is how old a lock file may get before it is assumed to be left over from a crash
+const staleLockAge = 1 * time.Hour
+
+// AcquireLock takes an exclusive, process-wide lock with the given name.
+// The returned function releases the lock.
+func AcquireLock(name string) (func(), error) {
+	cacheDir, err := CacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	lockPath := filepath.Join(cacheDir, name+".lock")
+
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock: %w", err)
+		}
+
+		// Remove locks left behind by processes that died without cleaning up
+		info, statErr := os.Stat(lockPath)
+		if statErr != nil || time.Since(info.ModTime()) < staleLockAge {
+			break
+		}
+		os.Remove(lockPath)
+	}
+
+	holder := "another process"
+	if data, err := os.ReadFile(lockPath); err == nil {
+		if pid, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+			holder = fmt.Sprintf("process %d", pid)
+		}
+	}
+
+	return nil, fmt.Errorf("%s is locked by %s (remove %s if this is stale)", name, holder, lockPath)
+}
+
+// WaitLock takes the lock like AcquireLock, retrying for up to timeout while
+// another process holds it. It suits locks that are only held briefly.
+func WaitLock(name string, timeout time.Duration) (func(), error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		release, err := AcquireLock(name)
+		if err == nil || time.Now().After(deadline) {
+			return release, err
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
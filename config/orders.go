@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ordersFileName = "orders.json"
+
+// Conditional order states
+const (
+	OrderPending   = "pending"
+	OrderExecuted  = "executed"
+	OrderCancelled = "cancelled"
+	OrderExpired   = "expired"
+)
+
+// Order is a payment that is sent once the exchange rate meets a condition
+type Order struct {
+	ID                    string            `json:"id"`
+	Recipient             string            `json:"recipient"`
+	RecipientID           int               `json:"recipientId"`
+	Amount                float64           `json:"amount"`
+	FixedSource           bool              `json:"fixedSource,omitempty"`
+	Currency              string            `json:"currency"`
+	TargetCurrency        string            `json:"targetCurrency"`
+	Reference             string            `json:"reference,omitempty"`
+	Invoice               string            `json:"invoice,omitempty"`
+	Details               map[string]string `json:"details,omitempty"`
+	SourceAccount         int               `json:"sourceAccount,omitempty"`
+	PayIn                 string            `json:"payIn,omitempty"`
+	ProfileID             int               `json:"profileId"`
+	Condition             string            `json:"condition"`
+	CustomerTransactionID string            `json:"customerTransactionId"`
+	Status                string            `json:"status"`
+	CreatedAt             time.Time         `json:"createdAt"`
+	ExpiresAt             time.Time         `json:"expiresAt"`
+	CheckedAt             time.Time         `json:"checkedAt,omitempty"`
+	LastRate              float64           `json:"lastRate,omitempty"`
+	ExecutedAt            time.Time         `json:"executedAt,omitempty"`
+	TransferID            int               `json:"transferId,omitempty"`
+	Error                 string            `json:"error,omitempty"`
+}
+
+// AmountCurrency returns the currency Amount is in: the source currency for
+// orders that debit a fixed amount, the recipient's currency otherwise
+func (o Order) AmountCurrency() string {
+	if o.FixedSource {
+		return o.Currency
+	}
+	return o.TargetCurrency
+}
+
+// RateCondition is a comparison against an exchange rate, such as ">=1.10"
+type RateCondition struct {
+	Op    string
+	Value float64
+}
+
+// ParseRateCondition parses a condition of the form <op><rate> with op one of >=, <=, > or <
+func ParseRateCondition(s string) (RateCondition, error) {
+	s = strings.TrimSpace(s)
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(s, op) {
+			value, err := strconv.ParseFloat(strings.TrimSpace(s[len(op):]), 64)
+			if err != nil || value <= 0 {
+				return RateCondition{}, fmt.Errorf("invalid rate in condition %q", s)
+			}
+			return RateCondition{Op: op, Value: value}, nil
+		}
+	}
+	return RateCondition{}, fmt.Errorf("invalid rate condition %q: use >=, <=, > or < followed by a rate, e.g. \">=1.10\"", s)
+}
+
+// String formats the condition as it was written, such as ">=1.1"
+func (c RateCondition) String() string {
+	return c.Op + strconv.FormatFloat(c.Value, 'f', -1, 64)
+}
+
+// Holds reports whether rate meets the condition
+func (c RateCondition) Holds(rate float64) bool {
+	switch c.Op {
+	case ">=":
+		return rate >= c.Value
+	case "<=":
+		return rate <= c.Value
+	case ">":
+		return rate > c.Value
+	case "<":
+		return rate < c.Value
+	}
+	return false
+}
+
+// LoadOrders loads all conditional orders
+func LoadOrders() ([]Order, error) {
+	var orders []Order
+	if err := readConfigJSON(ordersFileName, "orders", &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// SaveOrders writes all conditional orders
+func SaveOrders(orders []Order) error {
+	return writeConfigJSON(ordersFileName, "orders", orders)
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const quotesFileName = "quotes.json"
+
+const (
+	// quotesLockTimeout is how long writing quotes waits for another process
+	quotesLockTimeout = 10 * time.Second
+	// quoteRetention is how long quotes are kept after they expired or were used
+	quoteRetention = 30 * 24 * time.Hour
+)
+
+// Quote states as shown by 'quotes list'
+const (
+	QuoteLive    = "live"
+	QuoteExpired = "expired"
+	QuoteUsed    = "used"
+)
+
+// StoredQuote remembers a quote created by the CLI and the transfer or balance conversion it was used for
+type StoredQuote struct {
+	ID             string    `json:"id"`
+	ProfileID      int       `json:"profileId"`
+	SourceCurrency string    `json:"sourceCurrency"`
+	TargetCurrency string    `json:"targetCurrency"`
+	SourceAmount   float64   `json:"sourceAmount"`
+	TargetAmount   float64   `json:"targetAmount"`
+	Rate           float64   `json:"rate"`
+	PayIn          string    `json:"payIn,omitempty"`
+	TargetAccount  int       `json:"targetAccount,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	UsedAt         time.Time `json:"usedAt,omitempty"`
+	TransferID     int       `json:"transferId,omitempty"`
+	MovementID     int       `json:"movementId,omitempty"` // balance movement of a conversion between balances
+}
+
+// Status returns whether the quote was used, has expired or is still live at now
+func (q StoredQuote) Status(now time.Time) string {
+	switch {
+	case q.TransferID != 0 || q.MovementID != 0:
+		return QuoteUsed
+	case !q.ExpiresAt.IsZero() && now.After(q.ExpiresAt):
+		return QuoteExpired
+	default:
+		return QuoteLive
+	}
+}
+
+// UsedFor describes what the quote was used for, such as "transfer 123", or returns "" if it was not used
+func (q StoredQuote) UsedFor() string {
+	switch {
+	case q.TransferID != 0:
+		return fmt.Sprintf("transfer %d", q.TransferID)
+	case q.MovementID != 0:
+		return fmt.Sprintf("conversion %d", q.MovementID)
+	default:
+		return ""
+	}
+}
+
+// LoadQuotes loads all stored quotes, keyed by quote ID
+func LoadQuotes() (map[string]StoredQuote, error) {
+	quotes := map[string]StoredQuote{}
+	if err := readConfigJSON(quotesFileName, "quotes", &quotes); err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
+// SetQuote creates or replaces a stored quote
+func SetQuote(quote StoredQuote) error {
+	return updateQuotes(func(quotes map[string]StoredQuote) {
+		quotes[quote.ID] = quote
+	})
+}
+
+// UpdateQuote applies fn to a stored quote, doing nothing if the quote is not stored
+func UpdateQuote(id string, fn func(*StoredQuote)) error {
+	return updateQuotes(func(quotes map[string]StoredQuote) {
+		quote, exists := quotes[id]
+		if !exists {
+			return
+		}
+		fn(&quote)
+		quotes[id] = quote
+	})
+}
+
+// updateQuotes changes the stored quotes under the "quotes" lock, since
+// scheduled payments and orders run from cron next to interactive commands.
+// Quotes that expired or were used more than quoteRetention ago are dropped.
+func updateQuotes(fn func(map[string]StoredQuote)) error {
+	release, err := WaitLock("quotes", quotesLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	quotes, err := LoadQuotes()
+	if err != nil {
+		return err
+	}
+
+	fn(quotes)
+
+	now := time.Now()
+	for id, quote := range quotes {
+		ended := quote.ExpiresAt
+		if !quote.UsedAt.IsZero() {
+			ended = quote.UsedAt
+		}
+		if !ended.IsZero() && now.Sub(ended) > quoteRetention {
+			delete(quotes, id)
+		}
+	}
+
+	return writeConfigJSON(quotesFileName, "quotes", quotes)
+}
+
+// LookupQuote returns the stored quote with the given ID, or nil if none exists
+func LookupQuote(id string) (*StoredQuote, error) {
+	quotes, err := LoadQuotes()
+	if err != nil {
+		return nil, err
+	}
+
+	quote, exists := quotes[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return &quote, nil
+}
+
+// SortedQuotes returns all stored quotes, newest first
+func SortedQuotes() ([]StoredQuote, error) {
+	quotes, err := LoadQuotes()
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]StoredQuote, 0, len(quotes))
+	for _, quote := range quotes {
+		sorted = append(sorted, quote)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	return sorted, nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	rateWatchesFileName    = "rate-watches.json"
+	rateWatchStateFileName = "rate-watch-state.json"
+)
+
+// RateWatch is an alert on the mid-market rate of a currency pair
+type RateWatch struct {
+	ID        string    `json:"id"`
+	Source    string    `json:"source"`
+	Target    string    `json:"target"`
+	Above     *float64  `json:"above,omitempty"`
+	Below     *float64  `json:"below,omitempty"`
+	Exec      string    `json:"exec,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RateWatchID returns the ID a watch gets when none is chosen, such as "eur-usd-above-1.12"
+func RateWatchID(source, target string, above, below *float64) string {
+	parts := []string{strings.ToLower(source), strings.ToLower(target)}
+	if above != nil {
+		parts = append(parts, "above", strconv.FormatFloat(*above, 'f', -1, 64))
+	}
+	if below != nil {
+		parts = append(parts, "below", strconv.FormatFloat(*below, 'f', -1, 64))
+	}
+	return strings.Join(parts, "-")
+}
+
+// Condition describes when the watch fires, such as ">= 1.12 or <= 1.05"
+func (w RateWatch) Condition() string {
+	var conditions []string
+	if w.Above != nil {
+		conditions = append(conditions, ">= "+strconv.FormatFloat(*w.Above, 'f', -1, 64))
+	}
+	if w.Below != nil {
+		conditions = append(conditions, "<= "+strconv.FormatFloat(*w.Below, 'f', -1, 64))
+	}
+	return strings.Join(conditions, " or ")
+}
+
+// Holds reports whether rate meets the watch's condition
+func (w RateWatch) Holds(rate float64) bool {
+	return (w.Above != nil && rate >= *w.Above) || (w.Below != nil && rate <= *w.Below)
+}
+
+// RateWatchState remembers whether a watch has fired, so that it fires once
+// per crossing instead of on every check
+type RateWatchState struct {
+	Triggered bool      `json:"triggered"`
+	LastRate  float64   `json:"lastRate"`
+	CheckedAt time.Time `json:"checkedAt"`
+	FiredAt   time.Time `json:"firedAt,omitempty"`
+}
+
+// LoadRateWatches loads the configured rate watches
+func LoadRateWatches() ([]RateWatch, error) {
+	var watches []RateWatch
+	if err := readConfigJSON(rateWatchesFileName, "rate watches", &watches); err != nil {
+		return nil, err
+	}
+	return watches, nil
+}
+
+// SaveRateWatches writes the configured rate watches
+func SaveRateWatches(watches []RateWatch) error {
+	return writeConfigJSON(rateWatchesFileName, "rate watches", watches)
+}
+
+// LoadRateWatchStates loads the alert state of all watches, keyed by watch ID
+func LoadRateWatchStates() (map[string]RateWatchState, error) {
+	states := map[string]RateWatchState{}
+	if err := readConfigJSON(rateWatchStateFileName, "rate watch state", &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// SaveRateWatchStates writes the alert state of all watches
+func SaveRateWatchStates(states map[string]RateWatchState) error {
+	return writeConfigJSON(rateWatchStateFileName, "rate watch state", states)
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+const recipientsFileName = "recipients.json"
+
+// LoadRecipientCreations loads when recipients were created with the CLI, keyed by recipient ID
+func LoadRecipientCreations() (map[int]time.Time, error) {
+	created := map[int]time.Time{}
+	if err := readConfigJSON(recipientsFileName, "recipient creations", &created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// RecordRecipientCreated remembers when a recipient was created
+func RecordRecipientCreated(recipientID int, at time.Time) error {
+	created, err := LoadRecipientCreations()
+	if err != nil {
+		return err
+	}
+
+	created[recipientID] = at
+	return writeConfigJSON(recipientsFileName, "recipient creations", created)
+}
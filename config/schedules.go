@@ -0,0 +1,67 @@
+package config
+
+import "time"
+
+const schedulesFileName = "schedules.json"
+
+// Missed-run policies for scheduled payments
+const (
+	// PolicyCatchUp executes every occurrence that was missed since the last run
+	PolicyCatchUp = "catch-up"
+	// PolicySkip executes only the most recent missed occurrence and skips the rest
+	PolicySkip = "skip"
+)
+
+// ScheduleRun records the outcome of one scheduled occurrence
+type ScheduleRun struct {
+	Occurrence            time.Time `json:"occurrence"`
+	RanAt                 time.Time `json:"ranAt"`
+	Status                string    `json:"status"` // executed, skipped or failed
+	CustomerTransactionID string    `json:"customerTransactionId,omitempty"`
+	TransferID            int       `json:"transferId,omitempty"`
+	Error                 string    `json:"error,omitempty"`
+}
+
+// Schedule represents a recurring payment
+type Schedule struct {
+	ID            string            `json:"id"`
+	Recipient     string            `json:"recipient"`
+	Amount        float64           `json:"amount"`
+	Currency      string            `json:"currency"`
+	Reference     string            `json:"reference,omitempty"`
+	SourceAccount int               `json:"sourceAccount,omitempty"`
+	Details       map[string]string `json:"details,omitempty"`
+	ProfileID     int               `json:"profileId"`
+	Cron          string            `json:"cron"`
+	Policy        string            `json:"policy"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	LastRun       time.Time         `json:"lastRun"`
+	Runs          []ScheduleRun     `json:"runs,omitempty"`
+}
+
+// SplitDue divides the occurrences due since the last run, oldest first, into
+// those to skip and those to execute. The skip policy executes only the most
+// recent one; catch-up executes the most recent maxCatchUp (0 for no limit).
+func (s *Schedule) SplitDue(due []time.Time, maxCatchUp int) (skip, run []time.Time) {
+	skipCount := 0
+	if s.Policy == PolicySkip && len(due) > 0 {
+		skipCount = len(due) - 1
+	} else if maxCatchUp > 0 && len(due) > maxCatchUp {
+		skipCount = len(due) - maxCatchUp
+	}
+	return due[:skipCount], due[skipCount:]
+}
+
+// LoadSchedules loads all scheduled payments
+func LoadSchedules() ([]Schedule, error) {
+	var schedules []Schedule
+	if err := readConfigJSON(schedulesFileName, "schedules", &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// SaveSchedules writes all scheduled payments, replacing the previous file atomically
+func SaveSchedules(schedules []Schedule) error {
+	return writeConfigJSON(schedulesFileName, "schedules", schedules)
+}
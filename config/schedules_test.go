@@ -0,0 +1,44 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleSplitDue(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	due := make([]time.Time, 14)
+	for i := range due {
+		due[i] = start.AddDate(0, i, 0)
+	}
+
+	tests := []struct {
+		name       string
+		policy     string
+		due        []time.Time
+		maxCatchUp int
+		wantSkip   int
+		wantRun    int
+	}{
+		{"catch-up runs everything", PolicyCatchUp, due[:3], 12, 0, 3},
+		{"catch-up stops at the limit", PolicyCatchUp, due, 12, 2, 12},
+		{"catch-up at exactly the limit", PolicyCatchUp, due[:12], 12, 0, 12},
+		{"catch-up without a limit", PolicyCatchUp, due, 0, 0, 14},
+		{"skip runs only the latest", PolicySkip, due, 12, 13, 1},
+		{"skip with one due", PolicySkip, due[:1], 12, 0, 1},
+		{"nothing due", PolicySkip, nil, 12, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Schedule{Policy: tt.policy}
+			skip, run := s.SplitDue(tt.due, tt.maxCatchUp)
+			if len(skip) != tt.wantSkip || len(run) != tt.wantRun {
+				t.Fatalf("SplitDue = %d skipped, %d run; want %d skipped, %d run", len(skip), len(run), tt.wantSkip, tt.wantRun)
+			}
+			if len(run) > 0 && !run[len(run)-1].Equal(tt.due[len(tt.due)-1]) {
+				t.Errorf("latest run = %s, want the latest occurrence %s", run[len(run)-1], tt.due[len(tt.due)-1])
+			}
+		})
+	}
+}
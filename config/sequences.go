@@ -0,0 +1,51 @@
+package config
+
+import "time"
+
+const sequencesFileName = "sequences.json"
+
+// sequenceLockTimeout is how long reserving a number waits for another process
+const sequenceLockTimeout = 10 * time.Second
+
+// loadSequences reads all reference sequence counters
+func loadSequences() (map[string]int, error) {
+	sequences := map[string]int{}
+	if err := readConfigJSON(sequencesFileName, "sequences", &sequences); err != nil {
+		return nil, err
+	}
+	return sequences, nil
+}
+
+// LoadSequence returns the last used value of a reference sequence counter
+func LoadSequence(key string) (int, error) {
+	sequences, err := loadSequences()
+	if err != nil {
+		return 0, err
+	}
+
+	return sequences[key], nil
+}
+
+// ReserveSequence increments a reference sequence counter and returns the new
+// value. The counter is saved under the "sequences" lock before the number is
+// used, so no two payments get the same number; a payment that fails
+// afterwards leaves a gap instead.
+func ReserveSequence(key string) (int, error) {
+	release, err := WaitLock("sequences", sequenceLockTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	sequences, err := loadSequences()
+	if err != nil {
+		return 0, err
+	}
+
+	sequences[key]++
+	if err := writeConfigJSON(sequencesFileName, "sequences", sequences); err != nil {
+		return 0, err
+	}
+
+	return sequences[key], nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const privateKeyFileName = "sca-private.pem"
+
+// PrivateKeyPath returns the path of the key used to sign SCA challenges
+func PrivateKeyPath() (string, error) {
+	cacheDir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, privateKeyFileName), nil
+}
+
+// SavePrivateKey saves a PEM encoded SCA signing key to the cache directory
+func SavePrivateKey(pemData []byte) error {
+	keyPath, err := PrivateKeyPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(keyPath, pemData, 0600); err != nil {
+		return fmt.Errorf("failed to save private key: %w", err)
+	}
+
+	return nil
+}
+
+// LoadPrivateKey loads a PEM encoded SCA signing key, from path if given,
+// otherwise from the cache directory. A missing default key returns nil.
+func LoadPrivateKey(path string) ([]byte, error) {
+	if path == "" {
+		defaultPath, err := PrivateKeyPath()
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(defaultPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to read private key: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	return data, nil
+}
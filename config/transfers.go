@@ -1,12 +1,14 @@
 package config
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 )
 
+// transfersDirName is the directory holding transfers created by the CLI, one file per customer transaction ID
+const transfersDirName = "transfers"
+
 // TransferData represents stored transfer information
 type TransferData struct {
 	ID                    int     `json:"id"`
@@ -33,43 +35,21 @@ func SaveTransfer(customerTxID string, data TransferData) error {
 		return err
 	}
 
-	transfersDir := filepath.Join(cacheDir, "transfers")
-	if err := os.MkdirAll(transfersDir, 0755); err != nil {
+	if err := os.MkdirAll(filepath.Join(cacheDir, transfersDirName), 0755); err != nil {
 		return fmt.Errorf("failed to create transfers directory: %w", err)
 	}
 
-	transferPath := filepath.Join(transfersDir, customerTxID+".json")
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal transfer data: %w", err)
-	}
-
-	if err := os.WriteFile(transferPath, jsonData, 0644); err != nil {
-		return fmt.Errorf("failed to save transfer: %w", err)
-	}
-
-	return nil
+	return writeConfigJSON(filepath.Join(transfersDirName, customerTxID+".json"), "transfer", data)
 }
 
 // LoadTransfer loads transfer data by customer transaction ID
 func LoadTransfer(customerTxID string) (TransferData, error) {
-	cacheDir, err := CacheDir()
-	if err != nil {
+	var data TransferData
+	if err := readConfigJSON(filepath.Join(transfersDirName, customerTxID+".json"), "transfer", &data); err != nil {
 		return TransferData{}, err
 	}
-
-	transferPath := filepath.Join(cacheDir, "transfers", customerTxID+".json")
-	jsonData, err := os.ReadFile(transferPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return TransferData{}, fmt.Errorf("transfer not found: %s", customerTxID)
-		}
-		return TransferData{}, fmt.Errorf("failed to read transfer: %w", err)
-	}
-
-	var data TransferData
-	if err := json.Unmarshal(jsonData, &data); err != nil {
-		return TransferData{}, fmt.Errorf("failed to parse transfer: %w", err)
+	if data.ID == 0 {
+		return TransferData{}, fmt.Errorf("transfer not found: %s", customerTxID)
 	}
 
 	return data, nil
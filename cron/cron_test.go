@@ -0,0 +1,85 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"day 31 skips short months", "0 9 31 * *", date(2026, 1, 31, 9, 0), date(2026, 3, 31, 9, 0)},
+		{"day 30 skips February", "0 9 30 * *", date(2026, 1, 30, 10, 0), date(2026, 3, 30, 9, 0)},
+		{"February 29 waits for a leap year", "0 9 29 2 *", date(2026, 3, 1, 0, 0), date(2028, 2, 29, 9, 0)},
+		{"year end rolls over", "30 23 31 12 *", date(2026, 12, 31, 23, 30), date(2027, 12, 31, 23, 30)},
+		{"day of month or day of week, weekday first", "0 9 1 * 1", date(2026, 10, 18, 12, 0), date(2026, 10, 19, 9, 0)},
+		{"day of month or day of week, month day first", "0 9 1 * 1", date(2026, 10, 31, 10, 0), date(2026, 11, 1, 9, 0)},
+		{"day of month or day of week, mid month", "0 9 15 * 5", date(2026, 10, 10, 0, 0), date(2026, 10, 15, 9, 0)},
+		{"day of week only", "0 9 * * 1", date(2026, 10, 18, 0, 0), date(2026, 10, 19, 9, 0)},
+		{"day of month only", "0 9 1 * *", date(2026, 10, 18, 0, 0), date(2026, 11, 1, 9, 0)},
+		{"Sunday as 7", "0 9 * * 7", date(2026, 10, 19, 0, 0), date(2026, 10, 25, 9, 0)},
+		{"Sunday as 0", "0 9 * * 0", date(2026, 10, 19, 0, 0), date(2026, 10, 25, 9, 0)},
+		{"weekday range skips the weekend", "0 9 * * 1-5", date(2026, 10, 23, 10, 0), date(2026, 10, 26, 9, 0)},
+		{"minute step", "*/15 * * * *", date(2026, 10, 18, 10, 7), date(2026, 10, 18, 10, 15)},
+		{"strictly after", "0 9 * * *", date(2026, 10, 18, 9, 0), date(2026, 10, 19, 9, 0)},
+		{"never fires", "0 9 31 2 *", date(2026, 1, 1, 0, 0), time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+			if got := s.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", expr)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	s, err := Parse("0 9 1 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := s.Between(date(2026, 1, 1, 9, 0), date(2026, 4, 1, 9, 0))
+	want := []time.Time{date(2026, 2, 1, 9, 0), date(2026, 3, 1, 9, 0), date(2026, 4, 1, 9, 0)}
+	if len(got) != len(want) {
+		t.Fatalf("Between = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("Between[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,454 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is the type of a filterable field
+type Kind int
+
+const (
+	String Kind = iota
+	Number
+	Time
+)
+
+// Value is the value of a field in one record
+type Value struct {
+	Str  string
+	Num  float64
+	Time time.Time
+}
+
+// Record looks up field values of the record being matched
+type Record func(field string) Value
+
+// Expr is a compiled filter expression
+type Expr interface {
+	Match(r Record) bool
+}
+
+// Parse compiles a filter expression such as
+//
+//	amount>500 and currency:EUR and (recipient~"acme" or reference~invoice)
+//
+// against the given fields. Comparisons are field, operator, value:
+//
+//	:  =   equal (strings case-insensitive, dates by day)
+//	!=     not equal
+//	~      contains (strings only, case-insensitive)
+//	> >= < <=  ordering (numbers and dates)
+//
+// Comparisons combine with and, or, not and parentheses; adjacent
+// comparisons without an operator are joined with and.
+func Parse(expr string, fields map[string]Kind) (Expr, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, fields: fields}
+	if p.done() {
+		return nil, fmt.Errorf("empty filter expression")
+	}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q", p.peek().text)
+	}
+
+	return e, nil
+}
+
+// IsExpression reports whether s uses filter syntax rather than being a plain
+// search term: after any opening parentheses and nots, it must start with one
+// of the fields followed by an operator, as in "amount>500" or "not (status:x)".
+// Search terms such as "Invoice: 2026" or "ACME (UK)" are not expressions.
+func IsExpression(s string, fields map[string]Kind) bool {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return false
+	}
+
+	i := 0
+	for i < len(tokens) && (tokens[i].kind == tokenOpen || (tokens[i].kind == tokenWord && strings.EqualFold(tokens[i].text, "not"))) {
+		i++
+	}
+	if i+1 >= len(tokens) || tokens[i].kind != tokenWord || tokens[i+1].kind != tokenOp {
+		return false
+	}
+	_, ok := fields[strings.ToLower(tokens[i].text)]
+	return ok
+}
+
+// FieldNames returns the names of the fields, sorted
+func FieldNames(fields map[string]Kind) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOp
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+var operators = []string{">=", "<=", "!=", ":", "~", "=", ">", "<"}
+
+// tokenize splits an expression into words, quoted strings, operators and parentheses
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenOpen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenClose, ")"})
+			i++
+		case c == '"' || c == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string starting at position %d", i+1)
+			}
+			tokens = append(tokens, token{tokenString, b.String()})
+			i = j + 1
+		default:
+			if op := operatorAt(s, i); op != "" {
+				tokens = append(tokens, token{tokenOp, op})
+				i += len(op)
+				continue
+			}
+			// Words starting with a digit keep their colons, so unquoted
+			// timestamps such as 2026-01-01T10:00:00Z stay one value
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n()\"'", rune(s[j])) && (operatorAt(s, j) == "" || (s[j] == ':' && isDigit(s[i]))) {
+				j++
+			}
+			tokens = append(tokens, token{tokenWord, s[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// operatorAt returns the comparison operator starting at position i, if any
+func operatorAt(s string, i int) string {
+	for _, op := range operators {
+		if strings.HasPrefix(s[i:], op) {
+			return op
+		}
+	}
+	return ""
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	fields map[string]Kind
+}
+
+func (p *parser) done() bool  { return p.pos >= len(p.tokens) }
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	p.pos++
+	return t
+}
+
+// keyword returns the lower-cased next word, to check for and, or and not
+func (p *parser) keyword() string {
+	if p.done() || p.peek().kind != tokenWord {
+		return ""
+	}
+	return strings.ToLower(p.peek().text)
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword() == "or" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && p.peek().kind != tokenClose && p.keyword() != "or" {
+		if p.keyword() == "and" {
+			p.next()
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of filter expression")
+	}
+
+	if p.keyword() == "not" {
+		p.next()
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{e}, nil
+	}
+
+	if p.peek().kind == tokenOpen {
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || p.peek().kind != tokenClose {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.next()
+		return e, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenWord {
+		return nil, fmt.Errorf("expected a field name, got %q", fieldTok.text)
+	}
+	field := strings.ToLower(fieldTok.text)
+	kind, ok := p.fields[field]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q, available: %s", fieldTok.text, strings.Join(FieldNames(p.fields), ", "))
+	}
+
+	if p.done() || p.peek().kind != tokenOp {
+		return nil, fmt.Errorf("expected an operator after %q", fieldTok.text)
+	}
+	op := p.next().text
+
+	if p.done() || (p.peek().kind != tokenWord && p.peek().kind != tokenString) {
+		return nil, fmt.Errorf("expected a value after %s%s", fieldTok.text, op)
+	}
+	literal := p.next().text
+
+	c := comparison{field: field, kind: kind, op: op}
+	switch kind {
+	case String:
+		if op != ":" && op != "=" && op != "!=" && op != "~" {
+			return nil, fmt.Errorf("operator %s cannot be used with text field %s", op, field)
+		}
+		c.str = strings.ToLower(literal)
+	case Number:
+		if op == "~" {
+			return nil, fmt.Errorf("operator ~ cannot be used with number field %s", field)
+		}
+		n, err := strconv.ParseFloat(literal, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects a number, got %q", field, literal)
+		}
+		c.num = n
+	case Time:
+		if op == "~" {
+			return nil, fmt.Errorf("operator ~ cannot be used with date field %s", field)
+		}
+		if t, err := time.Parse("2006-01-02", literal); err == nil {
+			c.time, c.dateOnly = t, true
+		} else if t, err := time.Parse(time.RFC3339, literal); err == nil {
+			c.time = t
+		} else {
+			return nil, fmt.Errorf("%s expects a YYYY-MM-DD date or RFC 3339 timestamp, got %q", field, literal)
+		}
+	}
+
+	return c, nil
+}
+
+type andExpr struct{ left, right Expr }
+
+func (e andExpr) Match(r Record) bool { return e.left.Match(r) && e.right.Match(r) }
+
+type orExpr struct{ left, right Expr }
+
+func (e orExpr) Match(r Record) bool { return e.left.Match(r) || e.right.Match(r) }
+
+type notExpr struct{ inner Expr }
+
+func (e notExpr) Match(r Record) bool { return !e.inner.Match(r) }
+
+type comparison struct {
+	field    string
+	kind     Kind
+	op       string
+	str      string
+	num      float64
+	time     time.Time
+	dateOnly bool
+}
+
+func (c comparison) Match(r Record) bool {
+	v := r(c.field)
+
+	var cmp int
+	switch c.kind {
+	case String:
+		s := strings.ToLower(v.Str)
+		switch c.op {
+		case "~":
+			return strings.Contains(s, c.str)
+		case "!=":
+			return s != c.str
+		default:
+			return s == c.str
+		}
+	case Number:
+		switch {
+		case v.Num < c.num:
+			cmp = -1
+		case v.Num > c.num:
+			cmp = 1
+		}
+	case Time:
+		if v.Time.IsZero() {
+			return false
+		}
+		t := v.Time.UTC()
+		if c.dateOnly {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		}
+		cmp = t.Compare(c.time)
+	}
+
+	switch c.op {
+	case ":", "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// Compare orders two values of the given kind, returning -1, 0 or 1
+func Compare(kind Kind, a, b Value) int {
+	switch kind {
+	case Number:
+		switch {
+		case a.Num < b.Num:
+			return -1
+		case a.Num > b.Num:
+			return 1
+		}
+		return 0
+	case Time:
+		return a.Time.Compare(b.Time)
+	}
+	return strings.Compare(strings.ToLower(a.Str), strings.ToLower(b.Str))
+}
+
+// SortKey orders records by one field
+type SortKey struct {
+	Field      string
+	Kind       Kind
+	Descending bool
+}
+
+// ParseSort parses a comma-separated list of fields, each optionally prefixed
+// with - for descending order, such as "-amount,date"
+func ParseSort(spec string, fields map[string]Kind) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		key := SortKey{}
+		if strings.HasPrefix(part, "-") {
+			key.Descending = true
+			part = part[1:]
+		} else {
+			part = strings.TrimPrefix(part, "+")
+		}
+
+		kind, ok := fields[part]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort field %q, available: %s", part, strings.Join(FieldNames(fields), ", "))
+		}
+		key.Field, key.Kind = part, kind
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Less reports whether record a sorts before record b
+func Less(keys []SortKey, a, b Record) bool {
+	for _, key := range keys {
+		cmp := Compare(key.Kind, a(key.Field), b(key.Field))
+		if cmp == 0 {
+			continue
+		}
+		if key.Descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	}
+	return false
+}
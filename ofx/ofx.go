@@ -0,0 +1,126 @@
+package ofx
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/queries"
+)
+
+// Maximum field lengths defined by the OFX 1.0.2 specification
+const (
+	maxNameLength = 32
+	maxMemoLength = 255
+)
+
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// WriteStatement renders a balance statement as an OFX 1.0.2 bank statement.
+// The output only depends on the statement itself, so rendering the same
+// period twice produces identical files. Text is written as UTF-8, as the
+// header declares, so payee names keep their accents.
+func WriteStatement(w io.Writer, statement *queries.Statement, accountID string, from, to time.Time) error {
+	var b strings.Builder
+
+	b.WriteString("OFXHEADER:100\r\nDATA:OFXSGML\r\nVERSION:102\r\nSECURITY:NONE\r\nENCODING:UTF-8\r\nCHARSET:NONE\r\nCOMPRESSION:NONE\r\nOLDFILEUID:NONE\r\nNEWFILEUID:NONE\r\n\r\n")
+
+	b.WriteString("<OFX>\r\n")
+	b.WriteString("<SIGNONMSGSRSV1><SONRS>\r\n")
+	b.WriteString("<STATUS><CODE>0<SEVERITY>INFO</STATUS>\r\n")
+	fmt.Fprintf(&b, "<DTSERVER>%s\r\n", formatTime(to))
+	b.WriteString("<LANGUAGE>ENG\r\n")
+	b.WriteString("</SONRS></SIGNONMSGSRSV1>\r\n")
+
+	b.WriteString("<BANKMSGSRSV1><STMTTRNRS>\r\n")
+	b.WriteString("<TRNUID>0\r\n")
+	b.WriteString("<STATUS><CODE>0<SEVERITY>INFO</STATUS>\r\n")
+	b.WriteString("<STMTRS>\r\n")
+	fmt.Fprintf(&b, "<CURDEF>%s\r\n", escaper.Replace(statement.Query.Currency))
+	b.WriteString("<BANKACCTFROM>\r\n")
+	b.WriteString("<BANKID>WISE\r\n")
+	fmt.Fprintf(&b, "<ACCTID>%s\r\n", escaper.Replace(accountID))
+	b.WriteString("<ACCTTYPE>CHECKING\r\n")
+	b.WriteString("</BANKACCTFROM>\r\n")
+
+	b.WriteString("<BANKTRANLIST>\r\n")
+	fmt.Fprintf(&b, "<DTSTART>%s\r\n", formatTime(from))
+	fmt.Fprintf(&b, "<DTEND>%s\r\n", formatTime(to))
+	for _, t := range statement.Transactions {
+		posted, err := time.Parse(time.RFC3339, t.Date)
+		if err != nil {
+			return fmt.Errorf("invalid date %q on transaction %s: %w", t.Date, t.ReferenceNumber, err)
+		}
+
+		trnType := "CREDIT"
+		if t.Amount.Value < 0 {
+			trnType = "DEBIT"
+		}
+
+		b.WriteString("<STMTTRN>\r\n")
+		fmt.Fprintf(&b, "<TRNTYPE>%s\r\n", trnType)
+		fmt.Fprintf(&b, "<DTPOSTED>%s\r\n", formatTime(posted))
+		fmt.Fprintf(&b, "<TRNAMT>%.2f\r\n", t.Amount.Value)
+		fmt.Fprintf(&b, "<FITID>%s\r\n", escaper.Replace(t.ReferenceNumber))
+		if name := payee(t); name != "" {
+			fmt.Fprintf(&b, "<NAME>%s\r\n", escaper.Replace(truncate(name, maxNameLength)))
+		}
+		if memo := memo(t); memo != "" {
+			fmt.Fprintf(&b, "<MEMO>%s\r\n", escaper.Replace(truncate(memo, maxMemoLength)))
+		}
+		b.WriteString("</STMTTRN>\r\n")
+	}
+	b.WriteString("</BANKTRANLIST>\r\n")
+
+	b.WriteString("<LEDGERBAL>\r\n")
+	fmt.Fprintf(&b, "<BALAMT>%.2f\r\n", statement.EndOfStatementBalance.Value)
+	fmt.Fprintf(&b, "<DTASOF>%s\r\n", formatTime(to))
+	b.WriteString("</LEDGERBAL>\r\n")
+
+	b.WriteString("</STMTRS>\r\n")
+	b.WriteString("</STMTTRNRS></BANKMSGSRSV1>\r\n")
+	b.WriteString("</OFX>\r\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatTime formats a time in the OFX datetime format, always in UTC
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102150405") + "[0:GMT]"
+}
+
+// payee picks the counterparty name of a transaction
+func payee(t queries.StatementTransaction) string {
+	switch {
+	case t.Details.Recipient != nil && t.Details.Recipient.Name != "":
+		return t.Details.Recipient.Name
+	case t.Details.SenderName != "":
+		return t.Details.SenderName
+	case t.Details.Merchant != nil && t.Details.Merchant.Name != "":
+		return t.Details.Merchant.Name
+	}
+	return ""
+}
+
+// memo combines the description and payment reference of a transaction
+func memo(t queries.StatementTransaction) string {
+	parts := []string{}
+	if t.Details.Description != "" {
+		parts = append(parts, t.Details.Description)
+	}
+	if t.Details.PaymentReference != "" {
+		parts = append(parts, t.Details.PaymentReference)
+	}
+	return strings.Join(parts, " - ")
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,97 @@
+package paymentref
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Context holds the values available to reference templates
+type Context struct {
+	Time          time.Time
+	RecipientName string
+	Invoice       string
+	Seq           int
+}
+
+// Render expands the placeholders in a reference template:
+//
+//	{{date}}            the date as 2006-01-02
+//	{{date:LAYOUT}}     the date formatted with a Go time layout
+//	{{month}}           the month name and year, e.g. "October 2026"
+//	{{seq}}             a sequence number that increments with every payment
+//	{{recipient.name}}  the recipient's full name
+//	{{invoice}}         the value given with --invoice
+func Render(tmpl string, ctx Context) (string, error) {
+	var out strings.Builder
+
+	for {
+		start := strings.Index(tmpl, "{{")
+		if start < 0 {
+			out.WriteString(tmpl)
+			break
+		}
+		end := strings.Index(tmpl[start:], "}}")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated placeholder in reference template: %q", tmpl[start:])
+		}
+
+		out.WriteString(tmpl[:start])
+		value, err := expand(strings.TrimSpace(tmpl[start+2:start+end]), ctx)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(value)
+		tmpl = tmpl[start+end+2:]
+	}
+
+	return out.String(), nil
+}
+
+// Check reports syntax errors and unknown placeholders in a template without
+// requiring values for them
+func Check(tmpl string) error {
+	_, err := Render(tmpl, Context{
+		Time:          time.Now(),
+		RecipientName: "recipient",
+		Invoice:       "invoice",
+		Seq:           1,
+	})
+	return err
+}
+
+// UsesSeq reports whether a template contains the {{seq}} placeholder
+func UsesSeq(tmpl string) bool {
+	return strings.Contains(strings.ReplaceAll(tmpl, " ", ""), "{{seq}}")
+}
+
+// expand returns the value of a single placeholder
+func expand(name string, ctx Context) (string, error) {
+	switch {
+	case name == "date":
+		return ctx.Time.Format("2006-01-02"), nil
+	case strings.HasPrefix(name, "date:"):
+		layout := strings.TrimPrefix(name, "date:")
+		if layout == "" {
+			return "", fmt.Errorf("empty layout in {{%s}}", name)
+		}
+		return ctx.Time.Format(layout), nil
+	case name == "month":
+		return ctx.Time.Format("January 2006"), nil
+	case name == "seq":
+		return strconv.Itoa(ctx.Seq), nil
+	case name == "recipient.name":
+		if ctx.RecipientName == "" {
+			return "", fmt.Errorf("{{recipient.name}} used but the recipient has no name")
+		}
+		return ctx.RecipientName, nil
+	case name == "invoice":
+		if ctx.Invoice == "" {
+			return "", fmt.Errorf("{{invoice}} used but no invoice was given (use --invoice)")
+		}
+		return ctx.Invoice, nil
+	default:
+		return "", fmt.Errorf("unknown placeholder in reference template: {{%s}}", name)
+	}
+}
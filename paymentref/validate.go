@@ -0,0 +1,88 @@
+package paymentref
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rule describes the reference restrictions for payouts in one currency
+type Rule struct {
+	MaxLength int
+	// Allowed lists the permitted punctuation in addition to ASCII letters, digits and spaces
+	Allowed string
+	// Source names the document the restriction is taken from
+	Source string
+}
+
+// sepaCharset is the punctuation permitted by the SEPA basic Latin character set
+const sepaCharset = "/-?:().,'+"
+
+// fallbackRule is what most payment schemes accept: one 35 character line of
+// a SWIFT MT103 remittance field (field 70, 4*35x) in the SEPA character set.
+// It is only used to warn about references in currencies without a
+// documented rule, since Wise may still accept them.
+var fallbackRule = Rule{MaxLength: 35, Allowed: sepaCharset}
+
+// rules holds the documented reference restrictions per payout currency.
+// References breaking them are rejected before anything is created.
+var rules = map[string]Rule{
+	"EUR": {
+		MaxLength: 140,
+		Allowed:   sepaCharset,
+		Source:    "EPC SEPA Credit Transfer Rulebook, AT-05 unstructured remittance information; EPC217-08 SEPA basic Latin character set",
+	},
+}
+
+// RuleFor returns the documented reference restrictions for a payout
+// currency, and false if there are none
+func RuleFor(currency string) (Rule, bool) {
+	rule, ok := rules[strings.ToUpper(currency)]
+	return rule, ok
+}
+
+// Validate checks a rendered reference against the length and character
+// restrictions for the payout currency. Breaking a documented rule is an
+// error. For other currencies the reference is checked against the fallback
+// rule and any problem is returned as a warning instead.
+func Validate(reference, currency string) (string, error) {
+	currency = strings.ToUpper(currency)
+
+	rule, documented := RuleFor(currency)
+	if !documented {
+		if problem := check(reference, "most payment schemes", fallbackRule); problem != "" {
+			return fmt.Sprintf("%s; Wise may still accept it, the limits for %s are not documented", problem, currency), nil
+		}
+		return "", nil
+	}
+
+	if problem := check(reference, currency+" references", rule); problem != "" {
+		return "", fmt.Errorf("%s (%s)", problem, rule.Source)
+	}
+	return "", nil
+}
+
+// check describes the first way a reference breaks a rule, or returns "" if
+// it does not. scope names who the rule belongs to, e.g. "EUR references".
+func check(reference, scope string, rule Rule) string {
+	if length := utf8.RuneCountInString(reference); length > rule.MaxLength {
+		return fmt.Sprintf("reference %q is %d characters long, %s allow at most %d", reference, length, scope, rule.MaxLength)
+	}
+
+	for _, r := range reference {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == ' ':
+			continue
+		case strings.ContainsRune(rule.Allowed, r):
+			continue
+		default:
+			allowed := "letters, digits and spaces"
+			if rule.Allowed != "" {
+				allowed += " and " + rule.Allowed
+			}
+			return fmt.Sprintf("reference %q contains %q, %s allow only %s", reference, r, scope, allowed)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,125 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/dhamidi/wise-cli/config"
+)
+
+// AccountDetailsCurrency names the currency bank details receive
+type AccountDetailsCurrency struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// AccountDetail is one line of bank details, such as the IBAN or the account holder
+type AccountDetail struct {
+	Type   string `json:"type"` // ACCOUNT_HOLDER, IBAN, BIC, SWIFT_CODE, ACCOUNT_NUMBER, ROUTING_NUMBER, SORT_CODE, ...
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	Hidden bool   `json:"hidden"`
+}
+
+// ReceiveOption is a way to receive money into a balance, such as a local
+// transfer or an international SWIFT payment
+type ReceiveOption struct {
+	Type    string          `json:"type"` // LOCAL or INTERNATIONAL
+	Title   string          `json:"title"`
+	Details []AccountDetail `json:"details"`
+}
+
+// Value returns the first detail of one of the given types, or "" if the option has none
+func (o ReceiveOption) Value(types ...string) string {
+	for _, t := range types {
+		for _, d := range o.Details {
+			if d.Type == t {
+				return d.Body
+			}
+		}
+	}
+	return ""
+}
+
+// AccountDetails holds the bank details a profile receives money in one currency with
+type AccountDetails struct {
+	ID             int                    `json:"id"`
+	Currency       AccountDetailsCurrency `json:"currency"`
+	Title          string                 `json:"title"`
+	Subtitle       string                 `json:"subtitle"`
+	Status         string                 `json:"status"` // ACTIVE, or AVAILABLE if not requested yet
+	Deprecated     bool                   `json:"deprecated"`
+	ReceiveOptions []ReceiveOption        `json:"receiveOptions"`
+}
+
+// ListAccountDetailsWithRefresh queries the Wise API for the bank details of a profile, optionally bypassing cache
+func ListAccountDetailsWithRefresh(apiToken string, profileID int, refresh bool) ([]AccountDetails, error) {
+	endpoint := fmt.Sprintf("https://api.wise.com/v1/profiles/%d/account-details", profileID)
+
+	// Generate cache key
+	cacheKey := generateCacheKey("account-details", fmt.Sprintf("%d", profileID))
+
+	// Check cache first
+	if cached, err := config.GetCacheEntryWithRefresh(cacheKey, refresh); err == nil && cached != "" {
+		var details []AccountDetails
+		if err := json.Unmarshal([]byte(cached), &details); err == nil {
+			return details, nil
+		}
+	}
+
+	httpReq, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+
+	client := &http.Client{}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account details: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+
+	var details []AccountDetails
+	if err := json.Unmarshal(body, &details); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Store in cache with HTTP headers
+	if err := config.SetCacheEntry(cacheKey, string(body), httpResp.Header); err != nil {
+		// Log error but don't fail the request
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache account details: %v\n", err)
+	}
+
+	return details, nil
+}
+
+// ActiveAccountDetails returns the bank details that can receive money,
+// optionally only those in one currency
+func ActiveAccountDetails(details []AccountDetails, currency string) []AccountDetails {
+	var active []AccountDetails
+	for _, d := range details {
+		if d.Status != "ACTIVE" || d.Deprecated {
+			continue
+		}
+		if currency != "" && !strings.EqualFold(d.Currency.Code, currency) {
+			continue
+		}
+		active = append(active, d)
+	}
+	return active
+}
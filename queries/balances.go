@@ -0,0 +1,116 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/dhamidi/wise-cli/config"
+)
+
+// Money is an amount in a currency
+type Money struct {
+	Value    float64 `json:"value"`
+	Currency string  `json:"currency"`
+}
+
+// Balance represents a multi-currency account balance
+type Balance struct {
+	ID               int     `json:"id"`
+	Currency         string  `json:"currency"`
+	Type             string  `json:"type"`
+	Name             *string `json:"name"`
+	Amount           Money   `json:"amount"`
+	ReservedAmount   Money   `json:"reservedAmount"`
+	CashAmount       Money   `json:"cashAmount"`
+	TotalWorth       Money   `json:"totalWorth"`
+	CreationTime     string  `json:"creationTime"`
+	ModificationTime string  `json:"modificationTime"`
+	Visible          bool    `json:"visible"`
+}
+
+// ListBalancesRequest holds parameters for listing balances
+type ListBalancesRequest struct {
+	ProfileID int
+	Types     []string // STANDARD, SAVINGS; defaults to STANDARD
+}
+
+// ListBalancesWithRefresh queries the Wise API for the balances of a profile, optionally bypassing cache
+func ListBalancesWithRefresh(apiToken string, req ListBalancesRequest, refresh bool) ([]Balance, error) {
+	types := req.Types
+	if len(types) == 0 {
+		types = []string{"STANDARD"}
+	}
+
+	params := url.Values{}
+	params.Set("types", strings.Join(types, ","))
+	queryStr := params.Encode()
+
+	endpoint := fmt.Sprintf("https://api.wise.com/v4/profiles/%d/balances?%s", req.ProfileID, queryStr)
+
+	// Generate cache key
+	cacheKey := generateCacheKey(fmt.Sprintf("balances-%d", req.ProfileID), queryStr)
+
+	// Check cache first
+	if cached, err := config.GetCacheEntryWithRefresh(cacheKey, refresh); err == nil && cached != "" {
+		var balances []Balance
+		if err := json.Unmarshal([]byte(cached), &balances); err == nil {
+			return balances, nil
+		}
+	}
+
+	httpReq, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+
+	client := &http.Client{}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balances: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+
+	var balances []Balance
+	if err := json.Unmarshal(body, &balances); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Store in cache with HTTP headers
+	if err := config.SetCacheEntry(cacheKey, string(body), httpResp.Header); err != nil {
+		// Log error but don't fail the request
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache balances: %v\n", err)
+	}
+
+	return balances, nil
+}
+
+// FindBalance returns the balance holding the given currency
+func FindBalance(balances []Balance, currency string) (*Balance, error) {
+	currencies := make([]string, 0, len(balances))
+	for i := range balances {
+		if strings.EqualFold(balances[i].Currency, currency) {
+			return &balances[i], nil
+		}
+		currencies = append(currencies, balances[i].Currency)
+	}
+	if len(currencies) == 0 {
+		return nil, fmt.Errorf("no %s balance: the profile has no balances", currency)
+	}
+	return nil, fmt.Errorf("no %s balance, available: %s", currency, strings.Join(currencies, ", "))
+}
@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"os"
+
+	"github.com/dhamidi/wise-cli/config"
 )
 
 // Quote represents a Wise exchange quote
@@ -122,3 +126,77 @@ func GetQuote(apiToken string, req GetQuoteRequest) (*Quote, error) {
 
 	return &quote, nil
 }
+
+// GetQuoteByID fetches an existing quote, such as the one a transfer was created from
+func GetQuoteByID(apiToken string, profileID int, quoteID string, refresh bool) (*Quote, error) {
+	endpoint := fmt.Sprintf("https://api.wise.com/v3/profiles/%d/quotes/%s", profileID, quoteID)
+
+	// Generate cache key
+	cacheKey := generateCacheKey("quote", fmt.Sprintf("%d/%s", profileID, quoteID))
+
+	// Check cache first
+	if cached, err := config.GetCacheEntryWithRefresh(cacheKey, refresh); err == nil && cached != "" {
+		var quote Quote
+		if err := json.Unmarshal([]byte(cached), &quote); err == nil {
+			return &quote, nil
+		}
+	}
+
+	httpReq, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+
+	client := &http.Client{}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quote: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+
+	var quote Quote
+	if err := json.Unmarshal(body, &quote); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Store in cache with HTTP headers
+	if err := config.SetCacheEntry(cacheKey, string(body), httpResp.Header); err != nil {
+		// Log error but don't fail the request
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache quote: %v\n", err)
+	}
+
+	return &quote, nil
+}
+
+// FeeFor returns the fee breakdown of the payment option a transfer of
+// sourceAmount was most likely paid with, preferring payment from a balance
+func (q *Quote) FeeFor(sourceAmount float64) (*Fee, bool) {
+	var best *PaymentOption
+	for i := range q.PaymentOptions {
+		option := &q.PaymentOptions[i]
+		if option.Disabled || (q.PayOut != "" && option.PayOut != q.PayOut) {
+			continue
+		}
+		if math.Abs(option.SourceAmount-sourceAmount) > 0.005 {
+			continue
+		}
+		if best == nil || option.PayIn == "BALANCE" {
+			best = option
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+	return &best.Fee, true
+}
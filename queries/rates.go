@@ -0,0 +1,125 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/config"
+)
+
+const (
+	// liveRateMaxAge is how long a current rate is served from the cache
+	liveRateMaxAge = time.Minute
+	// rateHistoryMaxAge is how long a rate history reaching up to now is served from the cache
+	rateHistoryMaxAge = 15 * time.Minute
+	// pastRateHistoryMaxAge is how long a rate history that ended before today is served from the cache
+	pastRateHistoryMaxAge = 24 * time.Hour
+)
+
+// Rate is the mid-market exchange rate between two currencies at a point in time
+type Rate struct {
+	Rate   float64 `json:"rate"`
+	Source string  `json:"source"`
+	Target string  `json:"target"`
+	Time   string  `json:"time"`
+}
+
+// ParsedTime returns the time of the rate, which the API reports as "2006-01-02T15:04:05-0700"
+func (r Rate) ParsedTime() (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04:05-0700", time.RFC3339} {
+		if t, err := time.Parse(layout, r.Time); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid rate time %q", r.Time)
+}
+
+// RatesRequest holds parameters for fetching exchange rates
+type RatesRequest struct {
+	Source string
+	Target string
+	From   *time.Time // with To, fetch the history instead of the current rate
+	To     *time.Time
+	Group  string // day, hour or minute
+}
+
+// GetRates fetches the current mid-market rate, or its history when From and To are set
+func GetRates(apiToken string, req RatesRequest, refresh bool) ([]Rate, error) {
+	params := url.Values{}
+	params.Set("source", strings.ToUpper(req.Source))
+	params.Set("target", strings.ToUpper(req.Target))
+
+	maxAge := liveRateMaxAge
+	if req.From != nil && req.To != nil {
+		params.Set("from", req.From.UTC().Format("2006-01-02T15:04:05"))
+		params.Set("to", req.To.UTC().Format("2006-01-02T15:04:05"))
+		if req.Group != "" {
+			params.Set("group", req.Group)
+		}
+
+		maxAge = rateHistoryMaxAge
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		if req.To.Before(today) {
+			maxAge = pastRateHistoryMaxAge
+		}
+	}
+	queryStr := params.Encode()
+
+	endpoint := "https://api.wise.com/v1/rates?" + queryStr
+
+	// Generate cache key
+	cacheKey := generateCacheKey("rates", queryStr)
+
+	// Check cache first
+	if cached, err := config.GetCacheEntryWithRefresh(cacheKey, refresh); err == nil && cached != "" {
+		var rates []Rate
+		if err := json.Unmarshal([]byte(cached), &rates); err == nil {
+			return rates, nil
+		}
+	}
+
+	httpReq, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+
+	client := &http.Client{}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rates: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+
+	var rates []Rate
+	if err := json.Unmarshal(body, &rates); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Rates change constantly, so the cache lifetime is set here rather
+	// than taken from the response headers
+	headers := http.Header{}
+	headers.Set("Cache-Control", fmt.Sprintf("max-age=%d", int(maxAge.Seconds())))
+	if err := config.SetCacheEntry(cacheKey, string(body), headers); err != nil {
+		// Log error but don't fail the request
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache rates: %v\n", err)
+	}
+
+	return rates, nil
+}
@@ -11,54 +11,142 @@ import (
 	"strings"
 
 	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/validation"
 )
 
 // Recipient represents a Wise recipient account
 type Recipient struct {
-	ID              int    `json:"id"`
-	CreatorID       int    `json:"creatorId"`
-	ProfileID       int    `json:"profileId"`
-	Name            Name   `json:"name"`
-	Currency        string `json:"currency"`
-	Country         string `json:"country"`
-	Type            string `json:"type"`
-	LegalEntityType string `json:"legalEntityType"`
-	Status          bool   `json:"status"`
-	Details         any    `json:"details"`
-	Hash            string `json:"hash"`
-	AccountSummary  string `json:"accountSummary"`
+	ID                 int              `json:"id"`
+	CreatorID          int              `json:"creatorId"`
+	ProfileID          int              `json:"profileId"`
+	Name               Name             `json:"name"`
+	Currency           string           `json:"currency"`
+	Country            string           `json:"country"`
+	Type               string           `json:"type"`
+	LegalEntityType    string           `json:"legalEntityType"`
+	Status             bool             `json:"status"`
+	Active             bool             `json:"active"`
+	Details            RecipientDetails `json:"details"`
+	Hash               string           `json:"hash"`
+	AccountSummary     string           `json:"accountSummary"`
+	LongAccountSummary string           `json:"longAccountSummary"`
+	OwnedByCustomer    bool             `json:"ownedByCustomer"`
+}
+
+// RecipientDetails holds the type-specific account details of a recipient,
+// such as iban, sortCode or address
+type RecipientDetails map[string]interface{}
+
+// Get returns a details field as a string, following dotted keys such as
+// "address.city" into nested objects
+func (d RecipientDetails) Get(key string) string {
+	var current interface{} = map[string]interface{}(d)
+	for _, part := range strings.Split(key, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current = m[part]
+	}
+
+	switch v := current.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}:
+		return ""
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Flatten returns all scalar details fields keyed by their dotted path
+func (d RecipientDetails) Flatten() map[string]string {
+	flat := make(map[string]string)
+	flattenDetails("", d, flat)
+	return flat
+}
+
+// flattenDetails walks nested details maps, collecting non-empty scalar values
+func flattenDetails(prefix string, m map[string]interface{}, flat map[string]string) {
+	for key, value := range m {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case nil:
+		case map[string]interface{}:
+			flattenDetails(path, v, flat)
+		case string:
+			if v != "" {
+				flat[path] = v
+			}
+		default:
+			flat[path] = fmt.Sprintf("%v", v)
+		}
+	}
 }
 
 // GetAccountNumber extracts account number from recipient details
 func (r *Recipient) GetAccountNumber() string {
-	if r.Details == nil {
-		return ""
+	// Try IBAN first (most common), then other field names that might contain account number
+	for _, field := range []string{"iban", "accountNumber", "number", "accountId", "id", "bic"} {
+		if val := r.Details.Get(field); val != "" {
+			return val
+		}
 	}
 
-	detailsMap, ok := r.Details.(map[string]interface{})
-	if !ok {
-		return ""
+	return ""
+}
+
+// bankCodeFields are details fields that, together with an account number,
+// identify a bank account on routes without IBANs
+var bankCodeFields = []string{"bankCode", "branchCode", "ifscCode", "bsbCode", "institutionNumber", "transitNumber", "clabe", "swiftCode", "bic"}
+
+// AccountKey returns a normalized identifier for the bank account that a set of
+// recipient details points at, or "" if the details carry no account identifier.
+// Recipients in the same currency with the same key pay the same account.
+func AccountKey(currency string, details map[string]interface{}) string {
+	d := RecipientDetails(details)
+	currency = strings.ToUpper(currency)
+
+	if iban := d.Get("iban"); iban != "" {
+		return currency + "/iban:" + validation.Normalize(iban)
 	}
 
-	// Try IBAN first (most common)
-	if iban, exists := detailsMap["iban"]; exists {
-		if str, ok := iban.(string); ok && str != "" {
-			return str
+	accountNumber := validation.Normalize(d.Get("accountNumber"))
+	if sortCode := d.Get("sortCode"); sortCode != "" && accountNumber != "" {
+		return currency + "/sort_code:" + validation.Normalize(sortCode) + "/" + accountNumber
+	}
+	for _, field := range []string{"abartn", "routingNumber"} {
+		if routing := d.Get(field); routing != "" && accountNumber != "" {
+			return currency + "/aba:" + validation.Normalize(routing) + "/" + accountNumber
 		}
 	}
+	if email := d.Get("email"); email != "" {
+		return currency + "/email:" + strings.ToLower(strings.TrimSpace(email))
+	}
 
-	// Try other field names that might contain account number
-	for _, field := range []string{"accountNumber", "number", "accountId", "id", "bic"} {
-		if val, exists := detailsMap[field]; exists {
-			if str, ok := val.(string); ok && str != "" {
-				return str
+	if accountNumber != "" {
+		key := currency + "/account:"
+		for _, field := range bankCodeFields {
+			if code := d.Get(field); code != "" {
+				key += validation.Normalize(code) + "/"
 			}
 		}
+		return key + accountNumber
 	}
 
 	return ""
 }
 
+// AccountKey returns the normalized account identifier of the recipient
+func (r *Recipient) AccountKey() string {
+	return AccountKey(r.Currency, r.Details)
+}
+
 type Name struct {
 	FullName   string `json:"fullName"`
 	GivenName  string `json:"givenName"`
@@ -195,3 +283,38 @@ func generateCacheKey(endpoint, queryStr string) string {
 	hash := md5.Sum([]byte(queryStr))
 	return fmt.Sprintf("%s-%x.json", strings.TrimSuffix(endpoint, ".json"), hash)
 }
+
+// GetRecipient fetches a single recipient account by ID
+func GetRecipient(apiToken string, recipientID int) (*Recipient, error) {
+	endpoint := fmt.Sprintf("https://api.wise.com/v2/accounts/%d", recipientID)
+
+	httpReq, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+
+	client := &http.Client{}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch recipient: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+
+	var recipient Recipient
+	if err := json.Unmarshal(body, &recipient); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &recipient, nil
+}
@@ -0,0 +1,263 @@
+package queries
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dhamidi/wise-cli/config"
+)
+
+// RequirementValue is one permitted value of a select or radio field
+type RequirementValue struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// RequirementField describes a single input required by Wise
+type RequirementField struct {
+	Key                         string             `json:"key"`
+	Name                        string             `json:"name"`
+	Type                        string             `json:"type"` // text, select, radio or date
+	RefreshRequirementsOnChange bool               `json:"refreshRequirementsOnChange"`
+	Required                    bool               `json:"required"`
+	DisplayFormat               *string            `json:"displayFormat"`
+	Example                     string             `json:"example"`
+	MinLength                   *int               `json:"minLength"`
+	MaxLength                   *int               `json:"maxLength"`
+	ValidationRegexp            *string            `json:"validationRegexp"`
+	ValuesAllowed               []RequirementValue `json:"valuesAllowed"`
+}
+
+// RequirementGroup groups the inputs that make up one logical field
+type RequirementGroup struct {
+	Name  string             `json:"name"`
+	Group []RequirementField `json:"group"`
+}
+
+// Requirement describes the fields needed for one account or transfer type
+type Requirement struct {
+	Type   string             `json:"type"`
+	Title  string             `json:"title"`
+	Fields []RequirementGroup `json:"fields"`
+}
+
+// Validate checks a value against the field's required flag, length limits,
+// regular expression and permitted values
+func (f *RequirementField) Validate(value string) error {
+	if value == "" {
+		if f.Required {
+			return fmt.Errorf("%s is required", f.Key)
+		}
+		return nil
+	}
+
+	length := utf8.RuneCountInString(value)
+	if f.MinLength != nil && length < *f.MinLength {
+		return fmt.Errorf("%s must be at least %d characters", f.Key, *f.MinLength)
+	}
+	if f.MaxLength != nil && length > *f.MaxLength {
+		return fmt.Errorf("%s must be at most %d characters", f.Key, *f.MaxLength)
+	}
+
+	// Wise writes some patterns for Java's regular expressions, for example
+	// with lookaheads, which Go cannot compile. Those values are left for
+	// Wise to check rather than rejected for a pattern nobody can match.
+	if f.ValidationRegexp != nil && *f.ValidationRegexp != "" {
+		re, err := regexp.Compile(*f.ValidationRegexp)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: cannot check the format of %s locally (unsupported pattern %s), Wise will check it\n", f.Key, *f.ValidationRegexp)
+		} else if !re.MatchString(value) {
+			if f.Example != "" {
+				return fmt.Errorf("%s has an invalid format (example: %s)", f.Key, f.Example)
+			}
+			return fmt.Errorf("%s has an invalid format (must match %s)", f.Key, *f.ValidationRegexp)
+		}
+	}
+
+	if len(f.ValuesAllowed) > 0 {
+		for _, allowed := range f.ValuesAllowed {
+			if allowed.Key == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of %s", f.Key, joinAllowedKeys(f.ValuesAllowed))
+	}
+
+	return nil
+}
+
+// joinAllowedKeys lists the keys of permitted values for error messages
+func joinAllowedKeys(values []RequirementValue) string {
+	keys := make([]string, len(values))
+	for i, v := range values {
+		keys[i] = v.Key
+	}
+	return strings.Join(keys, ", ")
+}
+
+// AccountRequirementsRequest holds parameters for fetching recipient account requirements
+type AccountRequirementsRequest struct {
+	SourceCurrency string
+	TargetCurrency string
+	SourceAmount   float64
+}
+
+// GetAccountRequirements fetches the fields needed to create a recipient for a currency route
+func GetAccountRequirements(apiToken string, req AccountRequirementsRequest, refresh bool) ([]Requirement, error) {
+	params := accountRequirementsParams(req)
+	queryStr := params.Encode()
+	endpoint := "https://api.wise.com/v1/account-requirements?" + queryStr
+
+	cacheKey := generateCacheKey("account-requirements", queryStr)
+
+	// Check cache first
+	if cached, err := config.GetCacheEntryWithRefresh(cacheKey, refresh); err == nil && cached != "" {
+		var requirements []Requirement
+		if err := json.Unmarshal([]byte(cached), &requirements); err == nil {
+			return requirements, nil
+		}
+	}
+
+	httpReq, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+	httpReq.Header.Set("Accept-Minor-Version", "1")
+
+	body, header, err := doRequirementsRequest(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var requirements []Requirement
+	if err := json.Unmarshal(body, &requirements); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Store in cache with HTTP headers
+	if err := config.SetCacheEntry(cacheKey, string(body), header); err != nil {
+		// Log error but don't fail the request
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache account requirements: %v\n", err)
+	}
+
+	return requirements, nil
+}
+
+// RefreshAccountRequirements posts the details entered so far and returns the
+// updated requirements, which may contain fields that depend on earlier answers
+func RefreshAccountRequirements(apiToken string, req AccountRequirementsRequest, account map[string]interface{}) ([]Requirement, error) {
+	jsonBody, err := json.Marshal(account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := "https://api.wise.com/v1/account-requirements?" + accountRequirementsParams(req).Encode()
+
+	httpReq, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept-Minor-Version", "1")
+
+	body, _, err := doRequirementsRequest(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var requirements []Requirement
+	if err := json.Unmarshal(body, &requirements); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return requirements, nil
+}
+
+// TransferRequirementsRequest holds the quote, recipient and details entered so
+// far for fetching the transfer requirements of a corridor
+type TransferRequirementsRequest struct {
+	TargetAccount int
+	QuoteUUID     string
+	Details       map[string]interface{}
+}
+
+// GetTransferRequirements fetches the details, such as the purpose of payment
+// or source of funds, that a transfer for the quote and recipient needs
+func GetTransferRequirements(apiToken string, req TransferRequirementsRequest) ([]Requirement, error) {
+	details := req.Details
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	payload := map[string]interface{}{
+		"targetAccount": req.TargetAccount,
+		"quoteUuid":     req.QuoteUUID,
+		"details":       details,
+	}
+
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest("POST", "https://api.wise.com/v1/transfer-requirements", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	body, _, err := doRequirementsRequest(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var requirements []Requirement
+	if err := json.Unmarshal(body, &requirements); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return requirements, nil
+}
+
+// accountRequirementsParams builds the query string for account requirement requests
+func accountRequirementsParams(req AccountRequirementsRequest) url.Values {
+	params := url.Values{}
+	params.Set("source", req.SourceCurrency)
+	params.Set("target", req.TargetCurrency)
+	params.Set("sourceAmount", fmt.Sprintf("%.2f", req.SourceAmount))
+	return params
+}
+
+// doRequirementsRequest sends a requirements request and returns the body and headers
+func doRequirementsRequest(httpReq *http.Request) ([]byte, http.Header, error) {
+	client := &http.Client{}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch requirements: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+
+	return body, httpResp.Header, nil
+}
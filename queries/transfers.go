@@ -43,14 +43,17 @@ type Transfer struct {
 	PayinSessionID        *string         `json:"payinSessionId"`
 }
 
+// transfersPageSize is the largest page the transfers endpoint returns
+const transfersPageSize = 100
+
 // ListTransfersRequest holds parameters for listing transfers
 type ListTransfersRequest struct {
 	ProfileID int
 	Status    string
 	Since     *time.Time
 	Until     *time.Time
-	Limit     int
-	Offset    int
+	Limit     int // maximum number of transfers in total, 0 for all
+	Offset    int // number of transfers to skip
 }
 
 // ListTransfers queries the Wise API for transfers with caching
@@ -58,8 +61,51 @@ func ListTransfers(apiToken string, req ListTransfersRequest) ([]Transfer, error
 	return ListTransfersWithRefresh(apiToken, req, false)
 }
 
-// ListTransfersWithRefresh queries the Wise API for transfers, optionally bypassing cache
+// ListTransfersWithRefresh queries the Wise API for all transfers matching
+// the request, following pages until the limit or the end of the results
 func ListTransfersWithRefresh(apiToken string, req ListTransfersRequest, refresh bool) ([]Transfer, error) {
+	var transfers []Transfer
+	err := EachTransfer(apiToken, req, refresh, func(t Transfer) error {
+		transfers = append(transfers, t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// EachTransfer calls fn for every transfer matching the request, fetching one
+// page at a time so that long histories are never held in memory at once.
+// Iteration stops at the first error returned by fn, which is passed through.
+func EachTransfer(apiToken string, req ListTransfersRequest, refresh bool, fn func(Transfer) error) error {
+	seen := 0
+	for offset := req.Offset; ; offset += transfersPageSize {
+		pageSize := transfersPageSize
+		if req.Limit > 0 && req.Limit-seen < pageSize {
+			pageSize = req.Limit - seen
+		}
+
+		page, err := listTransfersPage(apiToken, req, offset, pageSize, refresh)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range page {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+		seen += len(page)
+
+		if len(page) < pageSize || (req.Limit > 0 && seen >= req.Limit) {
+			return nil
+		}
+	}
+}
+
+// listTransfersPage fetches a single page of transfers
+func listTransfersPage(apiToken string, req ListTransfersRequest, offset, limit int, refresh bool) ([]Transfer, error) {
 	// Build query parameters
 	params := url.Values{}
 
@@ -72,21 +118,17 @@ func ListTransfersWithRefresh(apiToken string, req ListTransfersRequest, refresh
 	}
 
 	if req.Since != nil {
-		params.Set("createdDateStart", req.Since.Format("2006-01-02T15:04:05Z"))
+		params.Set("createdDateStart", req.Since.UTC().Format("2006-01-02T15:04:05Z"))
 	}
 
 	if req.Until != nil {
-		params.Set("createdDateEnd", req.Until.Format("2006-01-02T15:04:05Z"))
+		params.Set("createdDateEnd", req.Until.UTC().Format("2006-01-02T15:04:05Z"))
 	}
 
-	if req.Limit > 0 {
-		params.Set("limit", fmt.Sprintf("%d", req.Limit))
-	} else {
-		params.Set("limit", "100") // Default limit
-	}
+	params.Set("limit", fmt.Sprintf("%d", limit))
 
-	if req.Offset > 0 {
-		params.Set("offset", fmt.Sprintf("%d", req.Offset))
+	if offset > 0 {
+		params.Set("offset", fmt.Sprintf("%d", offset))
 	}
 
 	endpoint := "https://api.wise.com/v1/transfers"
@@ -0,0 +1,82 @@
+package sca
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Do sends a request to the Wise API and returns the body of its successful
+// response. If Wise answers with a strong customer authentication challenge
+// (403 with X-2fa-Approval-Result: REJECTED), the one-time token is signed
+// with sign and the request is repeated with the X-2fa-Approval and
+// X-Signature headers. Requests with a body must be created with
+// http.NewRequest from a bytes.Buffer or bytes.Reader so it can be sent again.
+func Do(req *http.Request, sign Signer) ([]byte, error) {
+	body, challenge, err := send(req)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == "" {
+		return body, nil
+	}
+
+	if sign == nil {
+		return nil, fmt.Errorf("strong customer authentication required: create a signing key with 'wise sca keygen' and upload the public key to Wise")
+	}
+
+	signature, err := sign(challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to repeat request: %w", err)
+		}
+	}
+	retry.Header.Set("X-2fa-Approval", challenge)
+	retry.Header.Set("X-Signature", signature)
+
+	body, challenge, err = send(retry)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != "" {
+		return nil, fmt.Errorf("strong customer authentication failed: check that the public key uploaded to Wise matches your signing key")
+	}
+
+	return body, nil
+}
+
+// send sends a request and returns either the body of a successful response
+// or the one-time token of a strong customer authentication challenge
+func send(req *http.Request) ([]byte, string, error) {
+	client := &http.Client{}
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResp.StatusCode == http.StatusForbidden && httpResp.Header.Get("X-2fa-Approval-Result") == "REJECTED" {
+		oneTimeToken := httpResp.Header.Get("X-2fa-Approval")
+		if oneTimeToken == "" {
+			return nil, "", fmt.Errorf("strong customer authentication required but no one-time token was returned")
+		}
+		return nil, oneTimeToken, nil
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		return nil, "", fmt.Errorf("API error (status %d): %s", httpResp.StatusCode, string(body))
+	}
+
+	return body, "", nil
+}
@@ -0,0 +1,72 @@
+package sca
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+)
+
+// keyBits is the RSA key size Wise accepts for request signing
+const keyBits = 2048
+
+// Signer signs a one-time token and returns the value of the X-Signature header
+type Signer func(oneTimeToken string) (string, error)
+
+// ParsePrivateKey reads an RSA private key in PKCS#1 or PKCS#8 PEM format
+func ParsePrivateKey(pemData []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key must be an RSA key")
+	}
+
+	return key, nil
+}
+
+// NewSigner returns a Signer using SHA256 with RSA, as Wise expects
+func NewSigner(key *rsa.PrivateKey) Signer {
+	return func(oneTimeToken string) (string, error) {
+		digest := sha256.Sum256([]byte(oneTimeToken))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			return "", fmt.Errorf("failed to sign one-time token: %w", err)
+		}
+		return base64.StdEncoding.EncodeToString(signature), nil
+	}
+}
+
+// GenerateKey creates a new signing key and returns the private key and the
+// public key to upload to Wise, both PEM encoded
+func GenerateKey() (privatePEM, publicPEM []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	privatePEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	return privatePEM, publicPEM, nil
+}
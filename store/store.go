@@ -0,0 +1,372 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/queries"
+)
+
+const (
+	storeDirName   = "store"
+	stateFileName  = "state.json"
+	profilesFile   = "profiles.json"
+	transfersDir   = "transfers-%d"      // one file per month of creation, e.g. 2026-10.json
+	transfersFile  = "transfers-%d.json" // single file written by earlier versions
+	recipientsFile = "recipients-%d.json"
+	feesFile       = "fees-%d.json"
+)
+
+// ProfileState records when the data of one profile was last synced
+type ProfileState struct {
+	TransfersSyncedAt  time.Time `json:"transfersSyncedAt"`
+	Transfers          int       `json:"transfers"` // number of stored transfers
+	RecipientsSyncedAt time.Time `json:"recipientsSyncedAt"`
+}
+
+// State holds the sync watermarks of all profiles
+type State struct {
+	ProfilesSyncedAt time.Time            `json:"profilesSyncedAt"`
+	Profiles         map[int]ProfileState `json:"profiles"`
+}
+
+// Synced reports whether the transfers of a profile have been synced at least once
+func (s *State) Synced(profileID int) bool {
+	return !s.Profiles[profileID].TransfersSyncedAt.IsZero()
+}
+
+// Dir returns the directory holding the local store, creating it if needed
+func Dir() (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(cacheDir, storeDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create store directory: %w", err)
+	}
+
+	return dir, nil
+}
+
+// LoadState loads the sync watermarks
+func LoadState() (*State, error) {
+	state := &State{Profiles: map[int]ProfileState{}}
+	if err := readJSON(stateFileName, state); err != nil {
+		return nil, err
+	}
+	if state.Profiles == nil {
+		state.Profiles = map[int]ProfileState{}
+	}
+	return state, nil
+}
+
+// SaveState writes the sync watermarks
+func SaveState(state *State) error {
+	return writeJSON(stateFileName, state)
+}
+
+// updateState applies fn to the sync watermarks and saves them. The state is
+// read right before it is written, so that a long sync does not save a copy
+// loaded before its fetch over changes made in the meantime.
+func updateState(fn func(*State)) error {
+	state, err := LoadState()
+	if err != nil {
+		return err
+	}
+	fn(state)
+	return SaveState(state)
+}
+
+// LoadProfiles loads the stored profiles
+func LoadProfiles() ([]queries.Profile, error) {
+	var profiles []queries.Profile
+	if err := readJSON(profilesFile, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// SaveProfiles replaces the stored profiles
+func SaveProfiles(profiles []queries.Profile) error {
+	return writeJSON(profilesFile, profiles)
+}
+
+// transferMonth returns the month a transfer is stored under, from its creation time
+func transferMonth(t queries.Transfer) string {
+	if len(t.Created) >= 7 {
+		return t.Created[:7]
+	}
+	return "0000-00"
+}
+
+// transferMonths lists the months with stored transfers of a profile, newest first
+func transferMonths(profileID int) ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, fmt.Sprintf(transfersDir, profileID)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read stored transfers: %w", err)
+	}
+
+	var months []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			months = append(months, strings.TrimSuffix(entry.Name(), ".json"))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(months)))
+	return months, nil
+}
+
+// monthOverlaps reports whether transfers created in month can fall between
+// since and until, allowing a day either side for time zones
+func monthOverlaps(month string, since, until *time.Time) bool {
+	start, err := time.Parse("2006-01", month)
+	if err != nil {
+		return true
+	}
+	end := start.AddDate(0, 1, 0)
+	if since != nil && end.Before(since.Add(-24*time.Hour)) {
+		return false
+	}
+	if until != nil && start.After(until.Add(24*time.Hour)) {
+		return false
+	}
+	return true
+}
+
+// LoadTransferMonth loads the stored transfers of a profile created in one month, keyed by transfer ID
+func LoadTransferMonth(profileID int, month string) (map[int]queries.Transfer, error) {
+	transfers := map[int]queries.Transfer{}
+	name := filepath.Join(fmt.Sprintf(transfersDir, profileID), month+".json")
+	if err := readJSON(name, &transfers); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// SaveTransferMonth replaces the stored transfers of a profile created in one month
+func SaveTransferMonth(profileID int, month string, transfers map[int]queries.Transfer) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, fmt.Sprintf(transfersDir, profileID)), 0755); err != nil {
+		return fmt.Errorf("failed to create transfers directory: %w", err)
+	}
+
+	name := filepath.Join(fmt.Sprintf(transfersDir, profileID), month+".json")
+	return writeJSON(name, transfers)
+}
+
+// EachTransfer calls fn for the stored transfers of the given profiles,
+// newest first. Only the months that can hold transfers created between
+// since and until are read, one at a time, so callers still have to check
+// the creation time of each transfer. Returning an error from fn stops the
+// iteration and returns that error.
+func EachTransfer(profileIDs []int, since, until *time.Time, fn func(queries.Transfer) error) error {
+	profilesByMonth := make(map[string][]int)
+	for _, profileID := range profileIDs {
+		if err := migrateTransfers(profileID); err != nil {
+			return err
+		}
+		months, err := transferMonths(profileID)
+		if err != nil {
+			return err
+		}
+		for _, month := range months {
+			profilesByMonth[month] = append(profilesByMonth[month], profileID)
+		}
+	}
+
+	months := make([]string, 0, len(profilesByMonth))
+	for month := range profilesByMonth {
+		months = append(months, month)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(months)))
+
+	for _, month := range months {
+		if !monthOverlaps(month, since, until) {
+			continue
+		}
+
+		transfers := map[int]queries.Transfer{}
+		for _, profileID := range profilesByMonth[month] {
+			stored, err := LoadTransferMonth(profileID, month)
+			if err != nil {
+				return err
+			}
+			for id, t := range stored {
+				transfers[id] = t
+			}
+		}
+
+		for _, t := range SortedTransfers(transfers) {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateTransfers splits the single transfers file of earlier versions
+// into month files and records how many transfers it held
+func migrateTransfers(profileID int) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	legacyPath := filepath.Join(dir, fmt.Sprintf(transfersFile, profileID))
+	if _, err := os.Stat(legacyPath); err != nil {
+		return nil
+	}
+
+	transfers := map[int]queries.Transfer{}
+	if err := readJSON(fmt.Sprintf(transfersFile, profileID), &transfers); err != nil {
+		return err
+	}
+
+	byMonth := make(map[string]map[int]queries.Transfer)
+	for id, t := range transfers {
+		month := transferMonth(t)
+		if byMonth[month] == nil {
+			byMonth[month] = map[int]queries.Transfer{}
+		}
+		byMonth[month][id] = t
+	}
+	for month, monthTransfers := range byMonth {
+		if err := SaveTransferMonth(profileID, month, monthTransfers); err != nil {
+			return err
+		}
+	}
+
+	err = updateState(func(state *State) {
+		profileState := state.Profiles[profileID]
+		profileState.Transfers = len(transfers)
+		state.Profiles[profileID] = profileState
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(legacyPath); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", legacyPath, err)
+	}
+	return nil
+}
+
+// LoadRecipients loads the stored recipients of a profile, keyed by recipient ID
+func LoadRecipients(profileID int) (map[int]queries.Recipient, error) {
+	recipients := map[int]queries.Recipient{}
+	if err := readJSON(fmt.Sprintf(recipientsFile, profileID), &recipients); err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// SaveRecipients replaces the stored recipients of a profile
+func SaveRecipients(profileID int, recipients map[int]queries.Recipient) error {
+	return writeJSON(fmt.Sprintf(recipientsFile, profileID), recipients)
+}
+
+// LoadFees loads the fees taken from the quotes of a profile's transfers, keyed by transfer ID
+func LoadFees(profileID int) (map[int]float64, error) {
+	fees := map[int]float64{}
+	if err := readJSON(fmt.Sprintf(feesFile, profileID), &fees); err != nil {
+		return nil, err
+	}
+	return fees, nil
+}
+
+// SaveFees replaces the recorded fees of a profile
+func SaveFees(profileID int, fees map[int]float64) error {
+	return writeJSON(fmt.Sprintf(feesFile, profileID), fees)
+}
+
+// SortedTransfers returns transfers newest first, as the API lists them
+func SortedTransfers(transfers map[int]queries.Transfer) []queries.Transfer {
+	sorted := make([]queries.Transfer, 0, len(transfers))
+	for _, t := range transfers {
+		sorted = append(sorted, t)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Created != sorted[j].Created {
+			return sorted[i].Created > sorted[j].Created
+		}
+		return sorted[i].ID > sorted[j].ID
+	})
+	return sorted
+}
+
+// SortedRecipients returns recipients ordered by ID
+func SortedRecipients(recipients map[int]queries.Recipient) []queries.Recipient {
+	sorted := make([]queries.Recipient, 0, len(recipients))
+	for _, r := range recipients {
+		sorted = append(sorted, r)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	return sorted
+}
+
+// readJSON decodes a store file into v, leaving v untouched if the file does not exist
+func readJSON(name string, v interface{}) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// writeJSON replaces a store file atomically
+func writeJSON(name string, v interface{}) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+
+	path := filepath.Join(dir, name)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to save %s: %w", name, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to save %s: %w", name, err)
+	}
+
+	return nil
+}
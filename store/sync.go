@@ -0,0 +1,245 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dhamidi/wise-cli/config"
+	"github.com/dhamidi/wise-cli/queries"
+)
+
+// syncOverlap is how far before the last sync a delta sync starts, so that
+// transfers created around the previous sync are not missed
+const syncOverlap = 24 * time.Hour
+
+// recheckPeriod is how long after their creation transfers that have not
+// reached a final status are fetched again. Sent transfers can still bounce
+// back or be refunded; a transfer left waiting for longer is not worth
+// refetching the history in between on every sync.
+const recheckPeriod = 90 * 24 * time.Hour
+
+// expireLockTimeout is how long expiring recipients waits for a running sync
+const expireLockTimeout = 30 * time.Second
+
+// finalStatuses are transfer states that never change again
+var finalStatuses = map[string]bool{
+	"cancelled":      true,
+	"funds_refunded": true,
+	"bounced_back":   true,
+	"charged_back":   true,
+}
+
+// SyncResult summarizes what a sync changed
+type SyncResult struct {
+	NewTransfers     int
+	UpdatedTransfers int
+	Transfers        int
+	Since            *time.Time // start of the transfer window fetched, nil for a full sync
+}
+
+// SyncTransfers pulls the transfers of a profile created since the last sync,
+// or since the oldest transfer that had not reached a final status, whichever
+// is earlier. Transfers are only waited on for recheckPeriod after their
+// creation. With full set, the complete history is fetched. Callers hold the
+// "sync" lock.
+func SyncTransfers(apiToken string, profileID int, full bool) (*SyncResult, error) {
+	if err := migrateTransfers(profileID); err != nil {
+		return nil, err
+	}
+
+	state, err := LoadState()
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt := time.Now().UTC()
+	req := queries.ListTransfersRequest{ProfileID: profileID}
+
+	profileState := state.Profiles[profileID]
+	if !full && !profileState.TransfersSyncedAt.IsZero() {
+		since := profileState.TransfersSyncedAt.Add(-syncOverlap)
+		recheckSince := startedAt.Add(-recheckPeriod)
+		err := EachTransfer([]int{profileID}, &recheckSince, nil, func(t queries.Transfer) error {
+			if finalStatuses[t.Status] {
+				return nil
+			}
+			created, err := time.Parse("2006-01-02 15:04:05", t.Created)
+			if err != nil || created.Before(recheckSince) {
+				return nil
+			}
+			if created.Before(since) {
+				since = created
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		req.Since = &since
+	}
+
+	result := &SyncResult{Since: req.Since}
+	w := &transferWriter{profileID: profileID}
+	err = queries.EachTransfer(apiToken, req, true, func(t queries.Transfer) error {
+		previous, known, err := w.put(t)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !known:
+			result.NewTransfers++
+		case previous.Status != t.Status:
+			result.UpdatedTransfers++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync transfers: %w", err)
+	}
+
+	if err := w.flush(); err != nil {
+		return nil, err
+	}
+
+	err = updateState(func(state *State) {
+		profileState := state.Profiles[profileID]
+		profileState.TransfersSyncedAt = startedAt
+		profileState.Transfers += result.NewTransfers
+		state.Profiles[profileID] = profileState
+		result.Transfers = profileState.Transfers
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// transferWriter stores fetched transfers in their month files, keeping one
+// month in memory at a time. The API lists transfers newest first, so each
+// month file is usually loaded and written once per sync.
+type transferWriter struct {
+	profileID int
+	month     string
+	transfers map[int]queries.Transfer
+	dirty     bool
+}
+
+// put stores a transfer and returns the version it replaced, if any
+func (w *transferWriter) put(t queries.Transfer) (queries.Transfer, bool, error) {
+	if month := transferMonth(t); month != w.month || w.transfers == nil {
+		if err := w.flush(); err != nil {
+			return queries.Transfer{}, false, err
+		}
+		transfers, err := LoadTransferMonth(w.profileID, month)
+		if err != nil {
+			return queries.Transfer{}, false, err
+		}
+		w.month, w.transfers = month, transfers
+	}
+
+	previous, known := w.transfers[t.ID]
+	w.transfers[t.ID] = t
+	w.dirty = true
+	return previous, known, nil
+}
+
+// flush writes the month in memory if it changed
+func (w *transferWriter) flush() error {
+	if !w.dirty {
+		return nil
+	}
+	if err := SaveTransferMonth(w.profileID, w.month, w.transfers); err != nil {
+		return err
+	}
+	w.dirty = false
+	return nil
+}
+
+// SyncRecipients replaces the stored recipients of a profile with the current
+// list. Recipients that were deleted since are kept but marked inactive, so
+// that old transfers still resolve to a name.
+func SyncRecipients(apiToken string, profileID int) (int, error) {
+	stored, err := LoadRecipients(profileID)
+	if err != nil {
+		return 0, err
+	}
+
+	startedAt := time.Now().UTC()
+	current, err := queries.ListRecipientsWithRefresh(apiToken, queries.ListRecipientsRequest{
+		ProfileID: profileID,
+	}, true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sync recipients: %w", err)
+	}
+
+	for id, r := range stored {
+		r.Active = false
+		stored[id] = r
+	}
+	for _, r := range current {
+		stored[r.ID] = r
+	}
+
+	if err := SaveRecipients(profileID, stored); err != nil {
+		return 0, err
+	}
+
+	err = updateState(func(state *State) {
+		profileState := state.Profiles[profileID]
+		profileState.RecipientsSyncedAt = startedAt
+		state.Profiles[profileID] = profileState
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(current), nil
+}
+
+// ExpireRecipients makes the next local query pull recipients again. It takes
+// the "sync" lock, waiting for a running sync to finish, so that the sync
+// does not save recipients fetched before the change over the expiry.
+func ExpireRecipients() error {
+	state, err := LoadState()
+	if err != nil {
+		return err
+	}
+	if len(state.Profiles) == 0 {
+		return nil
+	}
+
+	unlock, err := config.WaitLock("sync", expireLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return updateState(func(state *State) {
+		for id, profileState := range state.Profiles {
+			profileState.RecipientsSyncedAt = time.Time{}
+			state.Profiles[id] = profileState
+		}
+	})
+}
+
+// SyncProfiles replaces the stored profiles with the current list
+func SyncProfiles(apiToken string) ([]queries.Profile, error) {
+	profiles, err := queries.ListProfilesWithRefresh(apiToken, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync profiles: %w", err)
+	}
+
+	if err := SaveProfiles(profiles); err != nil {
+		return nil, err
+	}
+
+	err = updateState(func(state *State) {
+		state.ProfilesSyncedAt = time.Now().UTC()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
@@ -0,0 +1,174 @@
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+)
+
+// FieldError reports a problem with a single input field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Errors collects all field errors found in one set of details
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// RecipientDetails checks the well-known bank detail fields of a recipient
+// details map and returns an error listing every invalid field
+func RecipientDetails(details map[string]interface{}) error {
+	var errs Errors
+
+	check := func(field string, validate func(string) error) {
+		value, ok := details[field].(string)
+		if !ok || value == "" {
+			return
+		}
+		if err := validate(value); err != nil {
+			errs = append(errs, FieldError{Field: field, Message: err.Error()})
+		}
+	}
+
+	check("iban", IBAN)
+	check("sortCode", SortCode)
+	check("abartn", ABARouting)
+	check("routingNumber", ABARouting)
+	check("bic", BIC)
+	check("swiftCode", BIC)
+	check("email", Email)
+
+	// UK account numbers are only checked alongside a sort code, other routes use other formats
+	if _, ok := details["sortCode"]; ok {
+		check("accountNumber", UKAccountNumber)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
+
+// Normalize removes spaces and dashes and upper-cases an identifier
+func Normalize(s string) string {
+	s = strings.ToUpper(s)
+	s = strings.ReplaceAll(s, " ", "")
+	s = strings.ReplaceAll(s, "-", "")
+	return s
+}
+
+// isDigits reports whether s consists only of ASCII digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isLetters reports whether s consists only of upper-case ASCII letters
+func isLetters(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isAlphanumeric reports whether s consists only of upper-case ASCII letters and digits
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// SortCode checks a UK sort code, accepting 123456, 12-34-56 and 12 34 56
+func SortCode(sortCode string) error {
+	normalized := Normalize(sortCode)
+	if len(normalized) != 6 || !isDigits(normalized) {
+		return fmt.Errorf("sort code must be 6 digits, got %q", sortCode)
+	}
+	return nil
+}
+
+// UKAccountNumber checks a UK bank account number
+func UKAccountNumber(accountNumber string) error {
+	normalized := Normalize(accountNumber)
+	if len(normalized) != 8 || !isDigits(normalized) {
+		return fmt.Errorf("UK account number must be 8 digits, got %q", accountNumber)
+	}
+	return nil
+}
+
+// ABARouting checks a US ABA routing number including its checksum digit
+func ABARouting(routingNumber string) error {
+	normalized := Normalize(routingNumber)
+	if len(normalized) != 9 || !isDigits(normalized) {
+		return fmt.Errorf("routing number must be 9 digits, got %q", routingNumber)
+	}
+
+	d := make([]int, 9)
+	for i, r := range normalized {
+		d[i] = int(r - '0')
+	}
+	sum := 3*(d[0]+d[3]+d[6]) + 7*(d[1]+d[4]+d[7]) + (d[2] + d[5] + d[8])
+	if sum%10 != 0 {
+		return fmt.Errorf("routing number %s fails the ABA checksum", normalized)
+	}
+
+	return nil
+}
+
+// BIC checks the structure of a BIC/SWIFT code: 4-letter bank code,
+// 2-letter country code, 2-character location and optional 3-character branch
+func BIC(bic string) error {
+	normalized := Normalize(bic)
+	if len(normalized) != 8 && len(normalized) != 11 {
+		return fmt.Errorf("BIC must be 8 or 11 characters, got %d", len(normalized))
+	}
+	if !isLetters(normalized[0:4]) {
+		return fmt.Errorf("BIC bank code %q must be 4 letters", normalized[0:4])
+	}
+	if !isLetters(normalized[4:6]) {
+		return fmt.Errorf("BIC country code %q must be 2 letters", normalized[4:6])
+	}
+	if !isAlphanumeric(normalized[6:8]) {
+		return fmt.Errorf("BIC location code %q must be letters or digits", normalized[6:8])
+	}
+	if len(normalized) == 11 && !isAlphanumeric(normalized[8:11]) {
+		return fmt.Errorf("BIC branch code %q must be letters or digits", normalized[8:11])
+	}
+	return nil
+}
+
+// Email checks the syntax of an email address
+func Email(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return fmt.Errorf("%q is not a valid email address", email)
+	}
+	at := strings.LastIndex(email, "@")
+	if !strings.Contains(email[at+1:], ".") {
+		return fmt.Errorf("%q is missing a domain", email)
+	}
+	return nil
+}
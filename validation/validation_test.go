@@ -0,0 +1,64 @@
+package validation
+
+import "testing"
+
+func TestIBAN(t *testing.T) {
+	tests := []struct {
+		iban  string
+		valid bool
+	}{
+		{"GB82WEST12345698765432", true},
+		{"DE89370400440532013000", true},
+		{"NL91ABNA0417164300", true},
+		{"FR1420041010050500013M02606", true},
+		{"de89 3704 0044 0532 0130 00", true},
+		{"DE89-3704-0044-0532-0130-00", true},
+		{"GB83WEST12345698765432", false},
+		{"DE89370400440532013001", false},
+		{"DE8937040044053201300", false},
+		{"DE893704004405320130000", false},
+		{"ZZ12345678901234567890123456789012345", false},
+		{"DEXX370400440532013000", false},
+		{"1289370400440532013000", false},
+		{"DE89_370400440532013000", false},
+		{"DE89", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		err := IBAN(tt.iban)
+		if tt.valid && err != nil {
+			t.Errorf("IBAN(%q) = %v, want valid", tt.iban, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("IBAN(%q) succeeded, want an error", tt.iban)
+		}
+	}
+}
+
+func TestABARouting(t *testing.T) {
+	tests := []struct {
+		routingNumber string
+		valid         bool
+	}{
+		{"021000021", true},
+		{"011000015", true},
+		{"121000358", true},
+		{"021000022", false},
+		{"120000021", false},
+		{"02100002", false},
+		{"0210000210", false},
+		{"02100002A", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		err := ABARouting(tt.routingNumber)
+		if tt.valid && err != nil {
+			t.Errorf("ABARouting(%q) = %v, want valid", tt.routingNumber, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("ABARouting(%q) succeeded, want an error", tt.routingNumber)
+		}
+	}
+}